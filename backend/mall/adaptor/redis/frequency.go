@@ -1,9 +1,99 @@
 // Package redis Redis操作层-频率控制模块
 // 职责: 封装频率控制相关的Redis操作
 // 用途: 限制用户操作频率,防止接口被滥用
+// 已实现:
+//   - 登录失败次数限制(按手机号、按IP分别计数)
+//
 // TODO: 待实现功能
-//   - 接口访问频率限制(如登录、发送短信等)
-//   - 基于IP的限流
-//   - 基于用户ID的限流
+//   - 接口访问频率限制(如发送短信等)
 //   - 支持滑动窗口算法
 package redis
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-redis/redis"
+	"mall/adaptor"
+	"mall/config"
+	"time"
+)
+
+// IFrequency 频率控制Redis操作接口
+type IFrequency interface {
+	GetLoginFailures(ctx context.Context, scene, target string) (int64, error)                       // 获取登录失败次数
+	IncrLoginFailure(ctx context.Context, scene, target string, expire time.Duration) (int64, error) // 累加登录失败次数
+	ClearLoginFailures(ctx context.Context, scene, target string) error                              // 清空登录失败次数
+}
+
+// Frequency 频率控制Redis操作实现
+type Frequency struct {
+	redis *redis.Client // Redis客户端
+}
+
+// NewFrequency 创建频率控制Redis操作实例
+// 参数: adaptor 适配器,提供Redis连接
+// 返回: Frequency实例
+// 调用链: service.NewService -> NewFrequency
+func NewFrequency(adaptor adaptor.IAdaptor) *Frequency {
+	return &Frequency{
+		redis: adaptor.GetRedis(),
+	}
+}
+
+// fmtLoginFailureKey 格式化登录失败次数的Redis键名
+// 格式: <服务名>:frequency:login_fail:<场景>:<计数对象>
+// 示例: edu.mall:frequency:login_fail:customer_password:mobile:13800000000
+func fmtLoginFailureKey(scene, target string) string {
+	return fmt.Sprintf("%s:frequency:login_fail:%s:%s", config.ServerFullName, scene, target)
+}
+
+// GetLoginFailures 获取登录失败次数
+// 参数:
+//   - ctx: 上下文
+//   - scene: 登录场景
+//   - target: 计数对象,如mobile:<手机号>、ip:<IP>
+//
+// 返回: 失败次数和错误信息,没有记录时返回0
+// 调用链: service.checkLoginLimit -> GetLoginFailures
+func (f *Frequency) GetLoginFailures(ctx context.Context, scene, target string) (int64, error) {
+	count, err := f.redis.Get(fmtLoginFailureKey(scene, target)).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return count, err
+}
+
+// IncrLoginFailure 累加登录失败次数
+// 参数:
+//   - ctx: 上下文
+//   - scene: 登录场景
+//   - target: 计数对象
+//   - expire: 计数过期时间,每次失败重新计时
+//
+// 返回: 累加后的失败次数和错误信息
+// 调用链: service.recordLoginFailure -> IncrLoginFailure
+func (f *Frequency) IncrLoginFailure(ctx context.Context, scene, target string, expire time.Duration) (int64, error) {
+	redisKey := fmtLoginFailureKey(scene, target)
+	var incrCmd *redis.IntCmd
+	_, err := f.redis.TxPipelined(func(pipe redis.Pipeliner) error {
+		incrCmd = pipe.Incr(redisKey)
+		pipe.Expire(redisKey, expire)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return incrCmd.Val(), nil
+}
+
+// ClearLoginFailures 清空登录失败次数
+// 参数:
+//   - ctx: 上下文
+//   - scene: 登录场景
+//   - target: 计数对象
+//
+// 返回: 错误信息
+// 调用链: service.MobilePasswordLogin -> ClearLoginFailures
+func (f *Frequency) ClearLoginFailures(ctx context.Context, scene, target string) error {
+	return f.redis.Del(fmtLoginFailureKey(scene, target)).Err()
+}
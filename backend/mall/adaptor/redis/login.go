@@ -0,0 +1,85 @@
+// Package redis Redis操作层-登录记录模块
+// 职责: 封装管理员登录记录相关的Redis存储操作
+// 存储内容: 管理员最近一次登录的时间和IP
+package redis
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-redis/redis"
+	"mall/adaptor"
+	"mall/config"
+	"strconv"
+)
+
+// LastLogin 最近一次登录记录
+type LastLogin struct {
+	LoginAt int64  `json:"login_at"` // 登录时间,毫秒时间戳
+	IP      string `json:"ip"`       // 登录IP
+}
+
+// ILoginRecord 登录记录Redis操作接口
+type ILoginRecord interface {
+	SetAdminLastLogin(ctx context.Context, userID int64, record *LastLogin) error // 记录管理员最近一次登录
+	GetAdminLastLogin(ctx context.Context, userID int64) (*LastLogin, error)      // 获取管理员最近一次登录(无记录返回nil)
+}
+
+// LoginRecord 登录记录Redis操作实现
+type LoginRecord struct {
+	redis *redis.Client // Redis客户端
+}
+
+// NewLoginRecord 创建登录记录Redis操作实例
+// 参数: adaptor 适配器,提供Redis连接
+// 返回: LoginRecord实例
+// 调用链: service.NewService -> NewLoginRecord
+func NewLoginRecord(adaptor adaptor.IAdaptor) *LoginRecord {
+	return &LoginRecord{
+		redis: adaptor.GetRedis(),
+	}
+}
+
+// fmtAdminLastLoginKey 格式化管理员最近登录记录的Redis键名
+// 格式: <服务名>:admin:last_login:<userID>
+// 示例: edu.mall:admin:last_login:1
+func fmtAdminLastLoginKey(userID int64) string {
+	return fmt.Sprintf("%s:admin:last_login:%d", config.ServerFullName, userID)
+}
+
+// SetAdminLastLogin 记录管理员最近一次登录
+// 参数:
+//   - ctx: 上下文
+//   - userID: 管理员ID
+//   - record: 登录记录
+//
+// 返回: 错误信息
+// 存储结构: Hash(login_at, ip),不设置过期时间
+// 调用链: service.loginSuccess -> SetAdminLastLogin
+func (l *LoginRecord) SetAdminLastLogin(ctx context.Context, userID int64, record *LastLogin) error {
+	return l.redis.HMSet(fmtAdminLastLoginKey(userID), map[string]interface{}{
+		"login_at": record.LoginAt,
+		"ip":       record.IP,
+	}).Err()
+}
+
+// GetAdminLastLogin 获取管理员最近一次登录
+// 参数:
+//   - ctx: 上下文
+//   - userID: 管理员ID
+//
+// 返回: 登录记录和错误信息,从未登录过返回nil
+// 调用链: service.loginSuccess -> GetAdminLastLogin
+func (l *LoginRecord) GetAdminLastLogin(ctx context.Context, userID int64) (*LastLogin, error) {
+	values, err := l.redis.HGetAll(fmtAdminLastLoginKey(userID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	loginAt, _ := strconv.ParseInt(values["login_at"], 10, 64)
+	return &LastLogin{
+		LoginAt: loginAt,
+		IP:      values["ip"],
+	}, nil
+}
@@ -0,0 +1,145 @@
+// Package redis Redis操作层-订单超时模块
+// 职责: 维护待支付订单的支付截止时间,供超时自动取消任务扫描
+// 存储结构: ZSet,member为订单ID,score为支付截止时间(毫秒时间戳)
+// 多实例: 扫描到的订单需先通过ClaimOrderDeadline认领(截止时间推迟为租约到期时间),认领成功的实例才处理该订单,
+// 处理完成后移除;ZSet丢失或漏记的订单由数据库扫描通过EnsureOrderDeadline补回
+package redis
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-redis/redis"
+	"mall/adaptor"
+	"mall/config"
+	"strconv"
+)
+
+// IOrderDeadline 订单超时Redis操作接口
+type IOrderDeadline interface {
+	AddOrderDeadline(ctx context.Context, orderID, deadline int64) error                  // 记录订单支付截止时间(毫秒时间戳)
+	EnsureOrderDeadline(ctx context.Context, orderID, deadline int64) error               // 订单不在ZSet中时记录支付截止时间
+	ListDueOrders(ctx context.Context, now, limit int64) ([]int64, error)                 // 获取支付截止时间已到的订单ID
+	ClaimOrderDeadline(ctx context.Context, orderID, now, leaseUntil int64) (bool, error) // 认领到期订单(截止时间推迟为租约到期时间,成功返回true)
+	RemoveOrderDeadline(ctx context.Context, orderID int64) error                         // 移除订单的支付截止时间
+}
+
+// OrderDeadline 订单超时Redis操作实现
+type OrderDeadline struct {
+	redis *redis.Client // Redis客户端
+}
+
+// NewOrderDeadline 创建订单超时Redis操作实例
+// 参数: adaptor 适配器,提供Redis连接
+// 返回: OrderDeadline实例
+// 调用链: service.NewService -> NewOrderDeadline
+func NewOrderDeadline(adaptor adaptor.IAdaptor) *OrderDeadline {
+	return &OrderDeadline{
+		redis: adaptor.GetRedis(),
+	}
+}
+
+// fmtOrderDeadlineKey 格式化订单支付截止时间的Redis键名
+// 格式: <服务名>:order:deadline
+// 示例: edu.mall:order:deadline
+func fmtOrderDeadlineKey() string {
+	return fmt.Sprintf("%s:order:deadline", config.ServerFullName)
+}
+
+// AddOrderDeadline 记录订单支付截止时间
+// 参数:
+//   - ctx: 上下文
+//   - orderID: 订单ID
+//   - deadline: 支付截止时间,毫秒时间戳
+//
+// 返回: 错误信息
+// 特性: 订单已存在时覆盖截止时间
+// 调用链: service.PlaceOrder -> AddOrderDeadline
+func (o *OrderDeadline) AddOrderDeadline(ctx context.Context, orderID, deadline int64) error {
+	return o.redis.ZAdd(fmtOrderDeadlineKey(), redis.Z{Score: float64(deadline), Member: orderID}).Err()
+}
+
+// EnsureOrderDeadline 订单不在ZSet中时记录支付截止时间
+// 参数:
+//   - ctx: 上下文
+//   - orderID: 订单ID
+//   - deadline: 支付截止时间,毫秒时间戳
+//
+// 返回: 错误信息
+// 特性: 使用ZADD NX,订单已存在(包括正在被认领处理)时不修改,不会打断其他实例的租约
+// 调用链: service.SweepTimeoutOrders -> EnsureOrderDeadline
+func (o *OrderDeadline) EnsureOrderDeadline(ctx context.Context, orderID, deadline int64) error {
+	return o.redis.ZAddNX(fmtOrderDeadlineKey(), redis.Z{Score: float64(deadline), Member: orderID}).Err()
+}
+
+// ListDueOrders 获取支付截止时间已到的订单ID
+// 参数:
+//   - ctx: 上下文
+//   - now: 当前时间,毫秒时间戳
+//   - limit: 最多返回条数
+//
+// 返回: 订单ID列表(按截止时间升序)和错误信息
+// 注意: 只读取不移除,处理前需调用ClaimOrderDeadline认领
+// 调用链: service.CancelTimeoutOrders -> ListDueOrders
+func (o *OrderDeadline) ListDueOrders(ctx context.Context, now, limit int64) ([]int64, error) {
+	members, err := o.redis.ZRangeByScore(fmtOrderDeadlineKey(), redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(now, 10),
+		Count: limit,
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(members))
+	for _, member := range members {
+		id, err := strconv.ParseInt(member, 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// claimOrderDeadlineScript 认领到期订单的Lua脚本
+// 订单仍在ZSet中且截止时间已到时,将截止时间改为租约到期时间并返回1,否则返回0
+var claimOrderDeadlineScript = redis.NewScript(`
+local score = redis.call('ZSCORE', KEYS[1], ARGV[1])
+if score and tonumber(score) <= tonumber(ARGV[2]) then
+	redis.call('ZADD', KEYS[1], ARGV[3], ARGV[1])
+	return 1
+end
+return 0
+`)
+
+// ClaimOrderDeadline 认领到期订单
+// 参数:
+//   - ctx: 上下文
+//   - orderID: 订单ID
+//   - now: 当前时间,毫秒时间戳
+//   - leaseUntil: 租约到期时间,毫秒时间戳
+//
+// 返回: 认领成功返回true,已被其他实例认领或已移除返回false
+// 特性:
+//   - 检查和续期在Lua脚本中原子执行,多个实例同时认领同一订单时只有一个能成功
+//   - 认领只把截止时间推迟到租约到期时间,不移除订单;处理完成后调用RemoveOrderDeadline移除,
+//     实例在处理中退出时订单在租约到期后重新被扫描到
+//
+// 调用链: service.CancelTimeoutOrders -> ClaimOrderDeadline
+func (o *OrderDeadline) ClaimOrderDeadline(ctx context.Context, orderID, now, leaseUntil int64) (bool, error) {
+	n, err := claimOrderDeadlineScript.Run(o.redis, []string{fmtOrderDeadlineKey()}, orderID, now, leaseUntil).Int64()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// RemoveOrderDeadline 移除订单的支付截止时间
+// 参数:
+//   - ctx: 上下文
+//   - orderID: 订单ID
+//
+// 返回: 错误信息
+// 调用链: service.cancelTimeoutOrder -> RemoveOrderDeadline
+func (o *OrderDeadline) RemoveOrderDeadline(ctx context.Context, orderID int64) error {
+	return o.redis.ZRem(fmtOrderDeadlineKey(), orderID).Err()
+}
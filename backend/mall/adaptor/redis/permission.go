@@ -0,0 +1,165 @@
+// Package redis Redis操作层-权限缓存模块
+// 职责: 缓存管理员的有效权限编码,避免每次请求都查询角色和权限
+// 存储结构:
+//   - 版本号: String,角色、权限或分配关系变化时自增,旧版本缓存随之失效
+//   - 权限编码: Set,键包含版本号,member为权限编码,带过期时间兜底
+//   - 超级管理员标记: String,键包含版本号,与权限编码同时写入、同时过期,不与权限编码混存
+package redis
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-redis/redis"
+	"mall/adaptor"
+	"mall/config"
+	"time"
+)
+
+const (
+	adminPermExpire      = 5 * time.Minute // 权限编码缓存过期时间
+	adminPermPlaceholder = ""              // 占位成员,使没有任何权限的管理员也能命中缓存
+)
+
+// AdminPerms 管理员的有效权限
+type AdminPerms struct {
+	IsSuper bool     // 是否超级管理员,超级管理员跳过权限校验
+	Codes   []string // 权限编码,超级管理员不缓存
+}
+
+// IPermCache 权限缓存Redis操作接口
+type IPermCache interface {
+	GetAdminPermVersion(ctx context.Context) (int64, error)                            // 获取权限缓存版本号
+	GetAdminPerms(ctx context.Context, version, userID int64) (*AdminPerms, error)     // 获取管理员有效权限(未命中返回nil)
+	SetAdminPerms(ctx context.Context, version, userID int64, perms *AdminPerms) error // 缓存管理员有效权限
+	ClearAdminPerms(ctx context.Context) error                                         // 清空全部管理员的权限缓存
+}
+
+// PermCache 权限缓存Redis操作实现
+type PermCache struct {
+	redis *redis.Client // Redis客户端
+}
+
+// NewPermCache 创建权限缓存Redis操作实例
+// 参数: adaptor 适配器,提供Redis连接
+// 返回: PermCache实例
+// 调用链: service.NewService -> NewPermCache
+func NewPermCache(adaptor adaptor.IAdaptor) *PermCache {
+	return &PermCache{
+		redis: adaptor.GetRedis(),
+	}
+}
+
+// fmtAdminPermVersionKey 格式化权限缓存版本号的Redis键名
+// 格式: <服务名>:admin:perm:version
+func fmtAdminPermVersionKey() string {
+	return fmt.Sprintf("%s:admin:perm:version", config.ServerFullName)
+}
+
+// fmtAdminPermKey 格式化管理员权限编码的Redis键名
+// 格式: <服务名>:admin:perm:<version>:<userID>
+// 示例: edu.mall:admin:perm:3:1
+func fmtAdminPermKey(version, userID int64) string {
+	return fmt.Sprintf("%s:admin:perm:%d:%d", config.ServerFullName, version, userID)
+}
+
+// fmtAdminSuperKey 格式化管理员超级管理员标记的Redis键名
+// 格式: <服务名>:admin:perm_super:<version>:<userID>
+// 示例: edu.mall:admin:perm_super:3:1
+func fmtAdminSuperKey(version, userID int64) string {
+	return fmt.Sprintf("%s:admin:perm_super:%d:%d", config.ServerFullName, version, userID)
+}
+
+// GetAdminPermVersion 获取权限缓存版本号
+// 参数: ctx 上下文
+// 返回: 版本号和错误信息,未设置时返回0
+// 注意: 需在查询数据库之前获取,写缓存时使用同一版本号,避免把失效前读到的数据写入新版本
+// 调用链: service.CheckPermission -> GetAdminPermVersion
+func (p *PermCache) GetAdminPermVersion(ctx context.Context) (int64, error) {
+	version, err := p.redis.Get(fmtAdminPermVersionKey()).Int64()
+	if err != nil {
+		if err == redis.Nil {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return version, nil
+}
+
+// GetAdminPerms 获取管理员有效权限
+// 参数:
+//   - ctx: 上下文
+//   - version: 权限缓存版本号
+//   - userID: 管理员ID
+//
+// 返回: 有效权限和错误信息,权限编码或超级管理员标记任一缺失视为未命中,返回nil
+// 调用链: service.CheckPermission -> GetAdminPerms
+func (p *PermCache) GetAdminPerms(ctx context.Context, version, userID int64) (*AdminPerms, error) {
+	var membersCmd *redis.StringSliceCmd
+	var superCmd *redis.StringCmd
+	_, err := p.redis.Pipelined(func(pipe redis.Pipeliner) error {
+		membersCmd = pipe.SMembers(fmtAdminPermKey(version, userID))
+		superCmd = pipe.Get(fmtAdminSuperKey(version, userID))
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+	members := membersCmd.Val()
+	super, err := superCmd.Result()
+	if err == redis.Nil || len(members) == 0 {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	codes := make([]string, 0, len(members))
+	for _, member := range members {
+		if member != adminPermPlaceholder {
+			codes = append(codes, member)
+		}
+	}
+	return &AdminPerms{
+		IsSuper: super == "1",
+		Codes:   codes,
+	}, nil
+}
+
+// SetAdminPerms 缓存管理员有效权限
+// 参数:
+//   - ctx: 上下文
+//   - version: 查询数据库前获取的权限缓存版本号
+//   - userID: 管理员ID
+//   - perms: 有效权限
+//
+// 返回: 错误信息
+// 调用链: service.CheckPermission -> SetAdminPerms
+func (p *PermCache) SetAdminPerms(ctx context.Context, version, userID int64, perms *AdminPerms) error {
+	key := fmtAdminPermKey(version, userID)
+	superKey := fmtAdminSuperKey(version, userID)
+	members := make([]interface{}, 0, len(perms.Codes)+1)
+	members = append(members, adminPermPlaceholder)
+	for _, code := range perms.Codes {
+		members = append(members, code)
+	}
+	super := "0"
+	if perms.IsSuper {
+		super = "1"
+	}
+	_, err := p.redis.TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.Del(key)
+		pipe.SAdd(key, members...)
+		pipe.Expire(key, adminPermExpire)
+		pipe.Set(superKey, super, adminPermExpire)
+		return nil
+	})
+	return err
+}
+
+// ClearAdminPerms 清空全部管理员的权限缓存
+// 参数: ctx 上下文
+// 返回: 错误信息
+// 实现: 版本号自增,旧版本的缓存不再被读取,由过期时间自动清理
+// 调用链: service.clearPermCache -> ClearAdminPerms
+func (p *PermCache) ClearAdminPerms(ctx context.Context) error {
+	return p.redis.Incr(fmtAdminPermVersionKey()).Err()
+}
@@ -0,0 +1,114 @@
+// Package redis Redis操作层-会话模块
+// 职责: 记录管理员已签发且未吊销的Token,实现会话吊销(修改密码、禁用账号等场景)
+// 存储结构: ZSet,member为Token唯一ID(jti),score为Token过期时间(秒级时间戳)
+package redis
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-redis/redis"
+	"mall/adaptor"
+	"mall/config"
+	"strconv"
+	"time"
+)
+
+// ISession 会话Redis操作接口
+type ISession interface {
+	AddAdminSession(ctx context.Context, userID int64, tokenID string, expireAt time.Time) error // 记录管理员会话
+	ExistAdminSession(ctx context.Context, userID int64, tokenID string) (bool, error)           // 判断管理员会话是否有效
+	RevokeAdminSessions(ctx context.Context, userID int64, keepTokenID string) error             // 吊销管理员会话(keepTokenID非空时保留该会话)
+}
+
+// Session 会话Redis操作实现
+type Session struct {
+	redis *redis.Client // Redis客户端
+}
+
+// NewSession 创建会话Redis操作实例
+// 参数: adaptor 适配器,提供Redis连接
+// 返回: Session实例
+// 调用链: service.NewService -> NewSession
+func NewSession(adaptor adaptor.IAdaptor) *Session {
+	return &Session{
+		redis: adaptor.GetRedis(),
+	}
+}
+
+// fmtAdminSessionKey 格式化管理员会话的Redis键名
+// 格式: <服务名>:admin:session:<userID>
+// 示例: edu.mall:admin:session:1
+func fmtAdminSessionKey(userID int64) string {
+	return fmt.Sprintf("%s:admin:session:%d", config.ServerFullName, userID)
+}
+
+// AddAdminSession 记录管理员会话
+// 参数:
+//   - ctx: 上下文
+//   - userID: 管理员ID
+//   - tokenID: Token唯一ID(jti)
+//   - expireAt: Token过期时间
+//
+// 返回: 错误信息
+// 特性: 写入时顺带清理已过期的会话,键的过期时间随最晚过期的Token延长
+// 调用链: service.genToken -> AddAdminSession
+func (s *Session) AddAdminSession(ctx context.Context, userID int64, tokenID string, expireAt time.Time) error {
+	key := fmtAdminSessionKey(userID)
+	_, err := s.redis.TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.ZRemRangeByScore(key, "-inf", strconv.FormatInt(time.Now().Unix(), 10))
+		pipe.ZAdd(key, redis.Z{Score: float64(expireAt.Unix()), Member: tokenID})
+		pipe.ExpireAt(key, expireAt)
+		return nil
+	})
+	return err
+}
+
+// ExistAdminSession 判断管理员会话是否有效
+// 参数:
+//   - ctx: 上下文
+//   - userID: 管理员ID
+//   - tokenID: Token唯一ID(jti)
+//
+// 返回: 会话存在且未过期返回true
+// 调用链: service.ParseToken -> ExistAdminSession
+func (s *Session) ExistAdminSession(ctx context.Context, userID int64, tokenID string) (bool, error) {
+	score, err := s.redis.ZScore(fmtAdminSessionKey(userID), tokenID).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return false, nil
+		}
+		return false, err
+	}
+	return int64(score) > time.Now().Unix(), nil
+}
+
+// RevokeAdminSessions 吊销管理员会话
+// 参数:
+//   - ctx: 上下文
+//   - userID: 管理员ID
+//   - keepTokenID: 需要保留的会话(如修改密码时的当前会话),为空时吊销全部会话
+//
+// 返回: 错误信息
+// 调用链: service.ChangePassword/ResetPassword -> RevokeAdminSessions
+func (s *Session) RevokeAdminSessions(ctx context.Context, userID int64, keepTokenID string) error {
+	key := fmtAdminSessionKey(userID)
+	if keepTokenID == "" {
+		return s.redis.Del(key).Err()
+	}
+
+	// 读出保留会话的过期时间,删除整个键后只写回保留会话
+	score, err := s.redis.ZScore(key, keepTokenID).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return s.redis.Del(key).Err()
+		}
+		return err
+	}
+	_, err = s.redis.TxPipelined(func(pipe redis.Pipeliner) error {
+		pipe.Del(key)
+		pipe.ZAdd(key, redis.Z{Score: score, Member: keepTokenID})
+		pipe.ExpireAt(key, time.Unix(int64(score), 0))
+		return nil
+	})
+	return err
+}
@@ -1,6 +1,6 @@
 // Package redis Redis操作层-验证码模块
 // 职责: 封装验证码相关的Redis存储操作
-// 存储内容: 滑块验证码的Key和Ticket
+// 存储内容: 滑块验证码的Key和Ticket、短信验证码及其尝试次数、短信下发冷却标记
 package redis
 
 import (
@@ -15,10 +15,15 @@ import (
 // IVerify 验证码Redis操作接口
 // 提供验证码Key和Ticket的存取操作
 type IVerify interface {
-	SetCaptchaKey(ctx context.Context, key string, value string, expire time.Duration) error    // 存储验证码Key
-	GetCaptchaKey(ctx context.Context, key string) (string, error)                              // 获取验证码Key(获取后删除)
-	SetCaptchaTicket(ctx context.Context, key string, value string, expire time.Duration) error // 存储验证码Ticket
-	GetCaptchaTicket(ctx context.Context, key string) (string, error)                           // 获取验证码Ticket(获取后删除)
+	SetCaptchaKey(ctx context.Context, key string, value string, expire time.Duration) error           // 存储验证码Key
+	GetCaptchaKey(ctx context.Context, key string) (string, error)                                     // 获取验证码Key(获取后删除)
+	SetCaptchaTicket(ctx context.Context, key string, value string, expire time.Duration) error        // 存储验证码Ticket
+	GetCaptchaTicket(ctx context.Context, key string) (string, error)                                  // 获取验证码Ticket(获取后删除)
+	SetSmsCode(ctx context.Context, scene, mobile, code string, expire time.Duration) error            // 存储短信验证码(不重置尝试次数)
+	GetSmsCode(ctx context.Context, scene, mobile string) (string, error)                              // 获取短信验证码
+	DelSmsCode(ctx context.Context, scene, mobile string) (bool, error)                                // 删除短信验证码,返回是否由本次调用删除
+	IncrSmsCodeAttempt(ctx context.Context, scene, mobile string, expire time.Duration) (int64, error) // 累加短信验证码尝试次数
+	LockSmsSend(ctx context.Context, mobile string, expire time.Duration) (bool, error)                // 设置短信下发冷却,冷却中返回false
 }
 
 // Verify 验证码Redis操作实现
@@ -50,6 +55,27 @@ func fmtVerifyCaptchaTicket(key string) string {
 	return fmt.Sprintf("%s:captcha:ticket:%s", config.ServerFullName, key)
 }
 
+// fmtVerifySmsCode 格式化短信验证码的Redis键名
+// 格式: <服务名>:captcha:sms:<场景>:<手机号>
+// 示例: edu.mall:captcha:sms:admin_login:13800000000
+func fmtVerifySmsCode(scene, mobile string) string {
+	return fmt.Sprintf("%s:captcha:sms:%s:%s", config.ServerFullName, scene, mobile)
+}
+
+// fmtVerifySmsCodeAttempt 格式化短信验证码尝试次数的Redis键名
+// 格式: <服务名>:captcha:sms:attempt:<场景>:<手机号>
+// 示例: edu.mall:captcha:sms:attempt:admin_login:13800000000
+func fmtVerifySmsCodeAttempt(scene, mobile string) string {
+	return fmt.Sprintf("%s:captcha:sms:attempt:%s:%s", config.ServerFullName, scene, mobile)
+}
+
+// fmtVerifySmsCooldown 格式化短信下发冷却的Redis键名
+// 格式: <服务名>:captcha:sms:cooldown:<手机号>
+// 示例: edu.mall:captcha:sms:cooldown:13800000000
+func fmtVerifySmsCooldown(mobile string) string {
+	return fmt.Sprintf("%s:captcha:sms:cooldown:%s", config.ServerFullName, mobile)
+}
+
 // SetCaptchaKey 存储验证码Key到Redis
 // 参数:
 //   - ctx: 上下文
@@ -67,18 +93,11 @@ func (v *Verify) SetCaptchaKey(ctx context.Context, key string, value string, ex
 // 参数:
 //   - ctx: 上下文
 //   - key: 验证码标识
-// 返回: 验证码答案(JSON格式)和错误信息
-// 特性: 获取后立即删除,防止重复使用
+// 返回: 验证码答案(JSON格式)和错误信息,不存在或已过期时返回空字符串
+// 特性: 获取和删除原子执行,并发提交同一验证码时只有一个请求能拿到答案
 // 调用链: service.CheckCaptcha -> GetCaptchaKey
 func (v *Verify) GetCaptchaKey(ctx context.Context, key string) (string, error) {
-	redisKey := fmtVerifyCaptchaKey(key)
-	get, err := v.redis.Get(redisKey).Result()
-	if err != nil {
-		return "", err
-	}
-	// 获取后删除,确保验证码只能使用一次
-	v.redis.Del(redisKey)
-	return get, nil
+	return v.getAndDel(fmtVerifyCaptchaKey(key))
 }
 
 // SetCaptchaTicket 存储验证码Ticket到Redis
@@ -98,16 +117,134 @@ func (v *Verify) SetCaptchaTicket(ctx context.Context, key string, value string,
 // 参数:
 //   - ctx: 上下文
 //   - key: Ticket标识
-// 返回: Ticket内容和错误信息
-// 特性: 获取后立即删除,防止重复使用
-// 调用链: service.Login -> GetCaptchaTicket
+// 返回: Ticket内容和错误信息,不存在或已过期时返回空字符串
+// 特性: 获取和删除原子执行,并发使用同一Ticket时只有一个请求能拿到
+// 调用链: service.checkTicket -> GetCaptchaTicket
 func (v *Verify) GetCaptchaTicket(ctx context.Context, key string) (string, error) {
-	redisKey := fmtVerifyCaptchaTicket(key)
-	get, err := v.redis.Get(redisKey).Result()
+	return v.getAndDel(fmtVerifyCaptchaTicket(key))
+}
+
+// getAndDel 获取键值并删除
+// 参数: redisKey Redis键名
+// 返回: 键值和错误信息,不存在、已过期或已被其他请求删除时返回空字符串
+// 特性: GET和DEL在同一事务中执行,只有DEL成功的一方拿到键值,并发请求中只有一个能使用
+func (v *Verify) getAndDel(redisKey string) (string, error) {
+	var getCmd *redis.StringCmd
+	var delCmd *redis.IntCmd
+	_, err := v.redis.TxPipelined(func(pipe redis.Pipeliner) error {
+		getCmd = pipe.Get(redisKey)
+		delCmd = pipe.Del(redisKey)
+		return nil
+	})
+	if err == redis.Nil {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if delCmd.Val() != 1 {
+		return "", nil
+	}
+	return getCmd.Val(), nil
+}
+
+// SetSmsCode 存储短信验证码到Redis
+// 参数:
+//   - ctx: 上下文
+//   - scene: 业务场景编码,不同场景的验证码互不通用
+//   - mobile: 手机号
+//   - code: 验证码
+//   - expire: 过期时间
+//
+// 返回: 错误信息
+// 特性: 重新下发验证码时不清空尝试次数,避免通过反复下发绕过尝试次数限制
+// 调用链: service.SendSmsCode -> SetSmsCode
+func (v *Verify) SetSmsCode(ctx context.Context, scene, mobile, code string, expire time.Duration) error {
+	return v.redis.Set(fmtVerifySmsCode(scene, mobile), code, expire).Err()
+}
+
+// GetSmsCode 获取短信验证码
+// 参数:
+//   - ctx: 上下文
+//   - scene: 业务场景编码
+//   - mobile: 手机号
+//
+// 返回: 验证码和错误信息,不存在或已过期时返回空字符串
+// 注意: 获取后不删除,校验通过后需调用DelSmsCode使其失效
+// 调用链: service.checkSmsCode -> GetSmsCode
+func (v *Verify) GetSmsCode(ctx context.Context, scene, mobile string) (string, error) {
+	get, err := v.redis.Get(fmtVerifySmsCode(scene, mobile)).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
 	if err != nil {
 		return "", err
 	}
-	// 获取后删除,确保Ticket只能使用一次
-	v.redis.Del(redisKey)
 	return get, nil
 }
+
+// delSmsCodeScript 删除短信验证码的Lua脚本
+// 验证码由本次调用删除时同时删除尝试次数并返回1,验证码不存在时不删除尝试次数,返回0
+var delSmsCodeScript = redis.NewScript(`
+if redis.call('DEL', KEYS[1]) == 1 then
+	redis.call('DEL', KEYS[2])
+	return 1
+end
+return 0
+`)
+
+// DelSmsCode 删除短信验证码及其尝试次数
+// 参数:
+//   - ctx: 上下文
+//   - scene: 业务场景编码
+//   - mobile: 手机号
+//
+// 返回: 验证码是否由本次调用删除和错误信息
+// 用途: 并发提交同一验证码时,只有成功删除的一方视为校验通过,保证验证码只能使用一次
+// 特性: 只有验证码由本次调用删除时才清空尝试次数,在Lua脚本中原子执行
+// 调用链: service.checkSmsCode -> DelSmsCode
+func (v *Verify) DelSmsCode(ctx context.Context, scene, mobile string) (bool, error) {
+	keys := []string{fmtVerifySmsCode(scene, mobile), fmtVerifySmsCodeAttempt(scene, mobile)}
+	n, err := delSmsCodeScript.Run(v.redis, keys).Int64()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// IncrSmsCodeAttempt 累加短信验证码尝试次数
+// 参数:
+//   - ctx: 上下文
+//   - scene: 业务场景编码
+//   - mobile: 手机号
+//   - expire: 计数过期时间,与验证码有效期一致
+//
+// 返回: 累加后的尝试次数和错误信息
+// 特性: 每次校验前先原子累加,并发校验时每个请求拿到不同的次数,超过上限的请求直接拒绝
+// 调用链: service.checkSmsCode -> IncrSmsCodeAttempt
+func (v *Verify) IncrSmsCodeAttempt(ctx context.Context, scene, mobile string, expire time.Duration) (int64, error) {
+	redisKey := fmtVerifySmsCodeAttempt(scene, mobile)
+	var incrCmd *redis.IntCmd
+	_, err := v.redis.TxPipelined(func(pipe redis.Pipeliner) error {
+		incrCmd = pipe.Incr(redisKey)
+		pipe.Expire(redisKey, expire)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return incrCmd.Val(), nil
+}
+
+// LockSmsSend 设置短信下发冷却
+// 参数:
+//   - ctx: 上下文
+//   - mobile: 手机号
+//   - expire: 冷却时间
+//
+// 返回: 设置成功返回true,冷却中返回false
+// 特性: 冷却按手机号计算,不区分场景,防止同一手机号被频繁下发短信
+// 调用链: service.SendSmsCode -> LockSmsSend
+func (v *Verify) LockSmsSend(ctx context.Context, mobile string, expire time.Duration) (bool, error) {
+	return v.redis.SetNX(fmtVerifySmsCooldown(mobile), 1, expire).Result()
+}
@@ -0,0 +1,204 @@
+// Package admin 管理员数据访问层-权限
+// 职责: 封装permission表的CRUD操作
+// 结构: 权限为树形结构,ParentID=-1为顶级,菜单下可挂子菜单和操作
+// 调用链: service -> repo -> GORM
+package admin
+
+import (
+	"context"
+	"mall/adaptor"
+	"mall/adaptor/repo/model"
+	"mall/adaptor/repo/query"
+	"mall/consts"
+	"mall/service/do"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// IPermission 权限数据访问接口
+type IPermission interface {
+	ListAllPermissions(ctx context.Context) ([]*model.Permission, error)                // 查询全部权限
+	ListPermissionsByIDs(ctx context.Context, ids []int64) ([]*model.Permission, error) // 根据ID列表查询权限
+	GetPermission(ctx context.Context, id int64) (*model.Permission, error)             // 根据ID获取权限
+	GetPermissionByCode(ctx context.Context, code string) (*model.Permission, error)    // 根据编码获取权限
+	CountChildren(ctx context.Context, id int64) (int64, error)                         // 统计子权限数量
+	CreatePermission(ctx context.Context, req *do.CreatePermission) (int64, error)      // 创建权限
+	UpdatePermission(ctx context.Context, req *do.UpdatePermission) error               // 更新权限
+	UpdatePermissionStatus(ctx context.Context, req *do.UpdatePermissionStatus) error   // 批量更新权限状态
+	DeletePermission(ctx context.Context, id int64) error                               // 删除权限
+}
+
+// Permission 权限数据访问实现
+type Permission struct {
+	db *gorm.DB // 数据库连接
+}
+
+// NewPermission 创建权限数据访问实例
+// 参数: adaptor 适配器,提供数据库连接
+// 返回: Permission实例
+// 调用链: service.NewService -> NewPermission
+func NewPermission(adaptor adaptor.IAdaptor) *Permission {
+	return &Permission{
+		db: adaptor.GetDB(),
+	}
+}
+
+// ListAllPermissions 查询全部权限
+// 参数: ctx 上下文
+// 返回: 权限列表和错误信息,按Sort、ID升序
+// 用途: 权限数量有限,整表读取后在内存中组装树、检测环
+// 调用链: service.GetPermissionTree -> repo.ListAllPermissions -> GORM.Find
+func (p *Permission) ListAllPermissions(ctx context.Context) ([]*model.Permission, error) {
+	qs := query.Use(p.db).Permission
+	return qs.WithContext(ctx).Order(qs.Sort, qs.ID).Find()
+}
+
+// ListPermissionsByIDs 根据ID列表查询权限
+// 参数:
+//   - ctx: 上下文
+//   - ids: 权限ID列表
+//
+// 返回: 权限列表和错误信息,按Sort、ID升序,ids为空时返回空列表
+// 调用链: service.UpdateRolePermissions/GetMyPermissions -> repo.ListPermissionsByIDs -> GORM.Find
+func (p *Permission) ListPermissionsByIDs(ctx context.Context, ids []int64) ([]*model.Permission, error) {
+	if len(ids) == 0 {
+		return []*model.Permission{}, nil
+	}
+	qs := query.Use(p.db).Permission
+	return qs.WithContext(ctx).Where(qs.ID.In(ids...)).Order(qs.Sort, qs.ID).Find()
+}
+
+// GetPermission 根据ID获取权限
+// 参数:
+//   - ctx: 上下文
+//   - id: 权限ID
+//
+// 返回: 权限对象和错误信息,不存在返回gorm.ErrRecordNotFound
+// 调用链: service.UpdatePermission -> repo.GetPermission -> GORM.First
+func (p *Permission) GetPermission(ctx context.Context, id int64) (*model.Permission, error) {
+	qs := query.Use(p.db).Permission
+	return qs.WithContext(ctx).Where(qs.ID.Eq(id)).First()
+}
+
+// GetPermissionByCode 根据编码获取权限
+// 参数:
+//   - ctx: 上下文
+//   - code: 权限编码
+//
+// 返回: 权限对象和错误信息,不存在返回gorm.ErrRecordNotFound
+// 用途: 权限编码唯一性校验
+// 调用链: service.checkPermCodeUnique -> repo.GetPermissionByCode -> GORM.First
+func (p *Permission) GetPermissionByCode(ctx context.Context, code string) (*model.Permission, error) {
+	qs := query.Use(p.db).Permission
+	return qs.WithContext(ctx).Where(qs.Code.Eq(code)).First()
+}
+
+// CountChildren 统计子权限数量
+// 参数:
+//   - ctx: 上下文
+//   - id: 权限ID
+//
+// 返回: 直接子节点数量和错误信息
+// 调用链: service.DeletePermission -> repo.CountChildren -> GORM.Count
+func (p *Permission) CountChildren(ctx context.Context, id int64) (int64, error) {
+	qs := query.Use(p.db).Permission
+	return qs.WithContext(ctx).Where(qs.ParentID.Eq(id)).Count()
+}
+
+// CreatePermission 创建权限
+// 参数:
+//   - ctx: 上下文
+//   - req: 创建权限请求DO对象
+//
+// 返回: 权限ID和错误信息
+// 业务逻辑: 默认启用,记录更新人
+// 调用链: service.CreatePermission -> repo.CreatePermission -> GORM.Create
+func (p *Permission) CreatePermission(ctx context.Context, req *do.CreatePermission) (int64, error) {
+	timeNow := time.Now()
+	qs := query.Use(p.db).Permission
+	addObj := &model.Permission{
+		Code:     req.Code,
+		Type:     req.Type,
+		Name:     req.Name,
+		PagePath: req.PagePath,
+		ParentID: req.ParentID,
+		Status:   consts.IsEnable, // 默认启用
+		Sort:     req.Sort,
+		Desc:     req.Desc,
+		CreateAt: timeNow,
+		UpdateAt: timeNow,
+		UpdateBy: req.AdminUserID, // 记录操作人
+	}
+	err := qs.WithContext(ctx).Create(addObj)
+	if err != nil {
+		return 0, err
+	}
+	return addObj.ID, nil
+}
+
+// UpdatePermission 更新权限
+// 参数:
+//   - ctx: 上下文
+//   - req: 更新权限请求DO对象
+//
+// 返回: 错误信息
+// 可更新字段: 编码、类型、名称、菜单路径、父级、排序、描述(允许清空或置0)
+// 调用链: service.UpdatePermission -> repo.UpdatePermission -> GORM.UpdateSimple
+func (p *Permission) UpdatePermission(ctx context.Context, req *do.UpdatePermission) error {
+	qs := query.Use(p.db).Permission
+	_, err := qs.WithContext(ctx).Where(qs.ID.Eq(req.ID)).UpdateSimple(
+		qs.Code.Value(req.Code),
+		qs.Type.Value(req.Type),
+		qs.Name.Value(req.Name),
+		qs.PagePath.Value(req.PagePath),
+		qs.ParentID.Value(req.ParentID),
+		qs.Sort.Value(req.Sort),
+		qs.Desc.Value(req.Desc),
+		qs.UpdateAt.Value(time.Now()),
+		qs.UpdateBy.Value(req.AdminUserID), // 记录更新人
+	)
+	return err
+}
+
+// UpdatePermissionStatus 批量更新权限状态
+// 参数:
+//   - ctx: 上下文
+//   - req: 更新状态请求DO对象
+//
+// 返回: 错误信息
+// 用途: 禁用时连同子孙节点一次更新
+// 调用链: service.UpdatePermissionStatus -> repo.UpdatePermissionStatus -> GORM.Updates
+func (p *Permission) UpdatePermissionStatus(ctx context.Context, req *do.UpdatePermissionStatus) error {
+	if len(req.IDs) == 0 {
+		return nil
+	}
+	qs := query.Use(p.db).Permission
+	_, err := qs.WithContext(ctx).Where(qs.ID.In(req.IDs...)).Updates(model.Permission{
+		Status:   req.Status,
+		UpdateAt: time.Now(),
+		UpdateBy: req.AdminUserID, // 记录更新人
+	})
+	return err
+}
+
+// DeletePermission 删除权限
+// 参数:
+//   - ctx: 上下文
+//   - id: 权限ID
+//
+// 返回: 错误信息
+// 注意: 物理删除,调用前需确认没有子权限
+// 事务: 同时删除该权限与角色的关联(role_permission)
+// 调用链: service.DeletePermission -> repo.DeletePermission -> GORM.Delete
+func (p *Permission) DeletePermission(ctx context.Context, id int64) error {
+	return query.Use(p.db).Transaction(func(tx *query.Query) error {
+		rp := tx.RolePermission
+		if _, err := rp.WithContext(ctx).Where(rp.PermissionID.Eq(id)).Delete(); err != nil {
+			return err
+		}
+		qs := tx.Permission
+		_, err := qs.WithContext(ctx).Where(qs.ID.Eq(id)).Delete()
+		return err
+	})
+}
@@ -0,0 +1,208 @@
+// Package admin 管理员数据访问层-角色
+// 职责: 封装roles表的CRUD操作
+// 调用链: service -> repo -> GORM
+package admin
+
+import (
+	"context"
+	"errors"
+	"mall/adaptor"
+	"mall/adaptor/repo/model"
+	"mall/adaptor/repo/query"
+	"mall/consts"
+	"mall/service/do"
+	"mall/utils/tools"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IRole 角色数据访问接口
+type IRole interface {
+	ListRoles(ctx context.Context, req *do.ListRole) ([]*model.Role, int64, error) // 分页查询角色列表
+	ListRolesByIDs(ctx context.Context, ids []int64) ([]*model.Role, error)        // 根据ID列表查询角色
+	GetRole(ctx context.Context, id int64) (*model.Role, error)                    // 根据ID获取角色
+	GetRoleByName(ctx context.Context, name string) (*model.Role, error)           // 根据名称获取角色
+	CreateRole(ctx context.Context, req *do.CreateRole) (int64, error)             // 创建角色
+	UpdateRole(ctx context.Context, req *do.UpdateRole) error                      // 更新角色
+	UpdateRoleStatus(ctx context.Context, req *do.UpdateRoleStatus) error          // 更新角色状态(启用/禁用)
+	DeleteRole(ctx context.Context, id int64) error                                // 删除角色
+}
+
+// ErrRoleInUse 角色已分配给管理员,不能删除
+var ErrRoleInUse = errors.New("admin: role in use")
+
+// Role 角色数据访问实现
+type Role struct {
+	db *gorm.DB // 数据库连接
+}
+
+// NewRole 创建角色数据访问实例
+// 参数: adaptor 适配器,提供数据库连接
+// 返回: Role实例
+// 调用链: service.NewService -> NewRole
+func NewRole(adaptor adaptor.IAdaptor) *Role {
+	return &Role{
+		db: adaptor.GetDB(),
+	}
+}
+
+// ListRoles 分页查询角色列表
+// 参数:
+//   - ctx: 上下文
+//   - req: 查询条件DO对象
+//
+// 返回: 当前页角色列表、总条数和错误信息
+// 过滤: 名称模糊匹配、状态
+// 调用链: service.ListRoles -> repo.ListRoles -> GORM.FindByPage
+func (r *Role) ListRoles(ctx context.Context, req *do.ListRole) ([]*model.Role, int64, error) {
+	qs := query.Use(r.db).Role
+	q := qs.WithContext(ctx)
+	if req.Name != "" {
+		q = q.Where(qs.Name.Like("%" + tools.EscapeLike(req.Name) + "%"))
+	}
+	if req.Status != 0 {
+		q = q.Where(qs.Status.Eq(req.Status))
+	}
+	return q.Order(qs.ID.Desc()).FindByPage(req.Offset, req.Limit)
+}
+
+// ListRolesByIDs 根据ID列表查询角色
+// 参数:
+//   - ctx: 上下文
+//   - ids: 角色ID列表
+//
+// 返回: 角色列表和错误信息,ids为空时返回空列表
+// 调用链: service.UpdateUserRoles -> repo.ListRolesByIDs -> GORM.Find
+func (r *Role) ListRolesByIDs(ctx context.Context, ids []int64) ([]*model.Role, error) {
+	if len(ids) == 0 {
+		return []*model.Role{}, nil
+	}
+	qs := query.Use(r.db).Role
+	return qs.WithContext(ctx).Where(qs.ID.In(ids...)).Order(qs.ID).Find()
+}
+
+// GetRole 根据ID获取角色
+// 参数:
+//   - ctx: 上下文
+//   - id: 角色ID
+//
+// 返回: 角色对象和错误信息,不存在返回gorm.ErrRecordNotFound
+// 调用链: service.UpdateRole/DeleteRole -> repo.GetRole -> GORM.First
+func (r *Role) GetRole(ctx context.Context, id int64) (*model.Role, error) {
+	qs := query.Use(r.db).Role
+	return qs.WithContext(ctx).Where(qs.ID.Eq(id)).First()
+}
+
+// GetRoleByName 根据名称获取角色
+// 参数:
+//   - ctx: 上下文
+//   - name: 角色名称
+//
+// 返回: 角色对象和错误信息,不存在返回gorm.ErrRecordNotFound
+// 用途: 角色名称唯一性校验
+// 调用链: service.checkRoleNameUnique -> repo.GetRoleByName -> GORM.First
+func (r *Role) GetRoleByName(ctx context.Context, name string) (*model.Role, error) {
+	qs := query.Use(r.db).Role
+	return qs.WithContext(ctx).Where(qs.Name.Eq(name)).First()
+}
+
+// CreateRole 创建角色
+// 参数:
+//   - ctx: 上下文
+//   - req: 创建角色请求DO对象
+//
+// 返回: 角色ID和错误信息
+// 业务逻辑: 默认启用,记录创建人和更新人
+// 调用链: service.CreateRole -> repo.CreateRole -> GORM.Create
+func (r *Role) CreateRole(ctx context.Context, req *do.CreateRole) (int64, error) {
+	timeNow := time.Now()
+	qs := query.Use(r.db).Role
+	addObj := &model.Role{
+		Name:     req.Name,
+		Desc:     req.Desc,
+		Status:   consts.IsEnable, // 默认启用
+		CreateAt: timeNow,
+		UpdateAt: timeNow,
+		CreateBy: req.AdminUserID, // 记录创建人
+		UpdateBy: req.AdminUserID,
+	}
+	err := qs.WithContext(ctx).Create(addObj)
+	if err != nil {
+		return 0, err
+	}
+	return addObj.ID, nil
+}
+
+// UpdateRole 更新角色
+// 参数:
+//   - ctx: 上下文
+//   - req: 更新角色请求DO对象
+//
+// 返回: 错误信息
+// 可更新字段: 名称、描述(描述允许清空)
+// 调用链: service.UpdateRole -> repo.UpdateRole -> GORM.UpdateSimple
+func (r *Role) UpdateRole(ctx context.Context, req *do.UpdateRole) error {
+	qs := query.Use(r.db).Role
+	_, err := qs.WithContext(ctx).Where(qs.ID.Eq(req.ID)).UpdateSimple(
+		qs.Name.Value(req.Name),
+		qs.Desc.Value(req.Desc),
+		qs.UpdateAt.Value(time.Now()),
+		qs.UpdateBy.Value(req.AdminUserID), // 记录更新人
+	)
+	return err
+}
+
+// UpdateRoleStatus 更新角色状态
+// 参数:
+//   - ctx: 上下文
+//   - req: 更新状态请求DO对象
+//
+// 返回: 错误信息
+// 状态值: consts.IsEnable(1)启用, consts.IsDisable(-1)禁用
+// 调用链: service.UpdateRoleStatus -> repo.UpdateRoleStatus -> GORM.Updates
+func (r *Role) UpdateRoleStatus(ctx context.Context, req *do.UpdateRoleStatus) error {
+	qs := query.Use(r.db).Role
+	_, err := qs.WithContext(ctx).Where(qs.ID.Eq(req.ID)).Updates(model.Role{
+		Status:   req.Status,
+		UpdateAt: time.Now(),
+		UpdateBy: req.AdminUserID, // 记录更新人
+	})
+	return err
+}
+
+// DeleteRole 删除角色
+// 参数:
+//   - ctx: 上下文
+//   - id: 角色ID
+//
+// 返回: 错误信息,角色不存在返回gorm.ErrRecordNotFound,已分配给管理员返回ErrRoleInUse
+// 注意: 物理删除
+// 事务:
+//   - 锁定角色行(SELECT ... FOR UPDATE)后校验角色未分配给任何管理员,并发分配该角色的ReplaceUserRoles在提交前阻塞
+//   - 同时删除该角色的权限关联(role_permission),避免残留
+//
+// 调用链: service.DeleteRole -> repo.DeleteRole -> GORM.Transaction
+func (r *Role) DeleteRole(ctx context.Context, id int64) error {
+	return query.Use(r.db).Transaction(func(tx *query.Query) error {
+		qs := tx.Role
+		if _, err := qs.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Select(qs.ID).Where(qs.ID.Eq(id)).Take(); err != nil {
+			return err
+		}
+		ur := tx.AdminUserRole
+		count, err := ur.WithContext(ctx).Where(ur.RoleID.Eq(id)).Count()
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrRoleInUse
+		}
+		rp := tx.RolePermission
+		if _, err = rp.WithContext(ctx).Where(rp.RoleID.Eq(id)).Delete(); err != nil {
+			return err
+		}
+		_, err = qs.WithContext(ctx).Where(qs.ID.Eq(id)).Delete()
+		return err
+	})
+}
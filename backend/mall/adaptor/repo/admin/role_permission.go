@@ -0,0 +1,123 @@
+// Package admin 管理员数据访问层-角色权限
+// 职责: 封装role_permission表的查询和整体替换操作
+// 调用链: service -> repo -> GORM
+package admin
+
+import (
+	"context"
+	"mall/adaptor"
+	"mall/adaptor/repo/model"
+	"mall/adaptor/repo/query"
+	"mall/service/do"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// IRolePermission 角色权限关联数据访问接口
+type IRolePermission interface {
+	ListPermissionIDsByRoles(ctx context.Context, roleIDs []int64) ([]int64, error)  // 查询多个角色拥有的权限ID(去重)
+	ReplaceRolePermissions(ctx context.Context, req *do.ReplaceRolePermission) error // 整体替换角色的权限
+}
+
+// RolePermission 角色权限关联数据访问实现
+type RolePermission struct {
+	db *gorm.DB // 数据库连接
+}
+
+// NewRolePermission 创建角色权限关联数据访问实例
+// 参数: adaptor 适配器,提供数据库连接
+// 返回: RolePermission实例
+// 调用链: service.NewService -> NewRolePermission
+func NewRolePermission(adaptor adaptor.IAdaptor) *RolePermission {
+	return &RolePermission{
+		db: adaptor.GetDB(),
+	}
+}
+
+// ListPermissionIDsByRoles 查询多个角色拥有的权限ID
+// 参数:
+//   - ctx: 上下文
+//   - roleIDs: 角色ID列表
+//
+// 返回: 去重后的权限ID列表和错误信息,roleIDs为空时返回空列表
+// 调用链: service.GetRolePermissions/GetMyPermissions -> repo.ListPermissionIDsByRoles -> GORM.Pluck
+func (r *RolePermission) ListPermissionIDsByRoles(ctx context.Context, roleIDs []int64) ([]int64, error) {
+	ids := make([]int64, 0)
+	if len(roleIDs) == 0 {
+		return ids, nil
+	}
+	qs := query.Use(r.db).RolePermission
+	err := qs.WithContext(ctx).Distinct(qs.PermissionID).Where(qs.RoleID.In(roleIDs...)).Pluck(qs.PermissionID, &ids)
+	return ids, err
+}
+
+// ReplaceRolePermissions 整体替换角色的权限
+// 参数:
+//   - ctx: 上下文
+//   - req: 替换请求DO对象,PermissionIDs为角色最终拥有的全部权限
+//
+// 返回: 错误信息
+// 事务: 读取现有关联后按差异删除多余的、新增缺少的,未变化的关联保持原样(保留原创建人和创建时间),
+// 同时更新角色的更新人和更新时间
+// 调用链: service.UpdateRolePermissions -> repo.ReplaceRolePermissions -> GORM.Transaction
+func (r *RolePermission) ReplaceRolePermissions(ctx context.Context, req *do.ReplaceRolePermission) error {
+	timeNow := time.Now()
+	return query.Use(r.db).Transaction(func(tx *query.Query) error {
+		rp := tx.RolePermission
+		var current []int64
+		if err := rp.WithContext(ctx).Where(rp.RoleID.Eq(req.RoleID)).Pluck(rp.PermissionID, &current); err != nil {
+			return err
+		}
+
+		// 1. 计算差异
+		target := make(map[int64]bool, len(req.PermissionIDs))
+		for _, id := range req.PermissionIDs {
+			target[id] = true
+		}
+		existed := make(map[int64]bool, len(current))
+		var removed []int64
+		for _, id := range current {
+			existed[id] = true
+			if !target[id] {
+				removed = append(removed, id)
+			}
+		}
+		var added []*model.RolePermission
+		for id := range target {
+			if existed[id] {
+				continue
+			}
+			added = append(added, &model.RolePermission{
+				RoleID:       req.RoleID,
+				PermissionID: id,
+				CreateAt:     timeNow,
+				UpdateAt:     timeNow,
+				CreateBy:     req.AdminUserID, // 记录创建人
+				UpdateBy:     req.AdminUserID,
+			})
+		}
+
+		// 2. 删除多余的关联
+		if len(removed) > 0 {
+			if _, err := rp.WithContext(ctx).Where(rp.RoleID.Eq(req.RoleID), rp.PermissionID.In(removed...)).Delete(); err != nil {
+				return err
+			}
+		}
+
+		// 3. 新增缺少的关联
+		if len(added) > 0 {
+			if err := rp.WithContext(ctx).Create(added...); err != nil {
+				return err
+			}
+		}
+
+		// 4. 记录角色的更新人
+		role := tx.Role
+		_, err := role.WithContext(ctx).Where(role.ID.Eq(req.RoleID)).UpdateSimple(
+			role.UpdateAt.Value(timeNow),
+			role.UpdateBy.Value(req.AdminUserID),
+		)
+		return err
+	})
+}
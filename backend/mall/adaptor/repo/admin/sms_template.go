@@ -0,0 +1,174 @@
+// Package admin 管理员数据访问层-短信模板
+// 职责: 封装sms_template表的CRUD操作
+// 调用链: service -> repo -> GORM
+package admin
+
+import (
+	"context"
+	"mall/adaptor"
+	"mall/adaptor/repo/model"
+	"mall/adaptor/repo/query"
+	"mall/consts"
+	"mall/service/do"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ISmsTemplate 短信模板数据访问接口
+type ISmsTemplate interface {
+	GetTemplateByScene(ctx context.Context, sceneCode string) (*model.SmsTemplate, error)      // 根据场景编码获取启用中的模板
+	GetTemplate(ctx context.Context, id int64) (*model.SmsTemplate, error)                     // 根据ID获取模板
+	ListTemplates(ctx context.Context, req *do.ListSmsTemplate) ([]*model.SmsTemplate, error)  // 查询模板列表
+	CountEnabledByScene(ctx context.Context, sceneCode string, excludeID int64) (int64, error) // 统计场景下启用中的模板数量
+	CreateTemplate(ctx context.Context, req *do.CreateSmsTemplate) (int64, error)              // 创建模板
+	UpdateTemplate(ctx context.Context, req *do.UpdateSmsTemplate) error                       // 更新模板
+	UpdateTemplateStatus(ctx context.Context, req *do.UpdateSmsTemplateStatus) error           // 更新模板状态(启用/禁用)
+}
+
+// SmsTemplate 短信模板数据访问实现
+type SmsTemplate struct {
+	db *gorm.DB // 数据库连接
+}
+
+// NewSmsTemplate 创建短信模板数据访问实例
+// 参数: adaptor 适配器,提供数据库连接
+// 返回: SmsTemplate实例
+// 调用链: service.NewService -> NewSmsTemplate
+func NewSmsTemplate(adaptor adaptor.IAdaptor) *SmsTemplate {
+	return &SmsTemplate{
+		db: adaptor.GetDB(),
+	}
+}
+
+// GetTemplateByScene 根据场景编码获取启用中的模板
+// 参数:
+//   - ctx: 上下文
+//   - sceneCode: 场景编码(consts.SmsSceneXxx)
+//
+// 返回: 模板对象和错误信息,不存在返回gorm.ErrRecordNotFound
+// 调用链: service.sendSms -> repo.GetTemplateByScene -> GORM.First
+func (s *SmsTemplate) GetTemplateByScene(ctx context.Context, sceneCode string) (*model.SmsTemplate, error) {
+	qs := query.Use(s.db).SmsTemplate
+	return qs.WithContext(ctx).Where(qs.SceneCode.Eq(sceneCode), qs.Status.Eq(consts.IsEnable)).First()
+}
+
+// GetTemplate 根据ID获取模板
+// 参数:
+//   - ctx: 上下文
+//   - id: 模板ID
+//
+// 返回: 模板对象和错误信息,不存在返回gorm.ErrRecordNotFound
+// 调用链: service.UpdateSmsTemplate/SendTestSms -> repo.GetTemplate -> GORM.First
+func (s *SmsTemplate) GetTemplate(ctx context.Context, id int64) (*model.SmsTemplate, error) {
+	qs := query.Use(s.db).SmsTemplate
+	return qs.WithContext(ctx).Where(qs.ID.Eq(id)).First()
+}
+
+// ListTemplates 查询模板列表
+// 参数:
+//   - ctx: 上下文
+//   - req: 查询条件,空值表示不过滤
+//
+// 返回: 模板列表(按ID倒序)和错误信息
+// 调用链: service.ListSmsTemplates -> repo.ListTemplates -> GORM.Find
+func (s *SmsTemplate) ListTemplates(ctx context.Context, req *do.ListSmsTemplate) ([]*model.SmsTemplate, error) {
+	qs := query.Use(s.db).SmsTemplate
+	q := qs.WithContext(ctx)
+	if req.SceneCode != "" {
+		q = q.Where(qs.SceneCode.Eq(req.SceneCode))
+	}
+	if req.Platform != "" {
+		q = q.Where(qs.Platform.Eq(req.Platform))
+	}
+	if req.Status != 0 {
+		q = q.Where(qs.Status.Eq(req.Status))
+	}
+	return q.Order(qs.ID.Desc()).Find()
+}
+
+// CountEnabledByScene 统计场景下启用中的模板数量
+// 参数:
+//   - ctx: 上下文
+//   - sceneCode: 场景编码
+//   - excludeID: 排除的模板ID(更新时排除自身),0表示不排除
+//
+// 返回: 数量和错误信息
+// 用途: 保证同一场景只有一个启用中的模板
+func (s *SmsTemplate) CountEnabledByScene(ctx context.Context, sceneCode string, excludeID int64) (int64, error) {
+	qs := query.Use(s.db).SmsTemplate
+	q := qs.WithContext(ctx).Where(qs.SceneCode.Eq(sceneCode), qs.Status.Eq(consts.IsEnable))
+	if excludeID > 0 {
+		q = q.Where(qs.ID.Neq(excludeID))
+	}
+	return q.Count()
+}
+
+// CreateTemplate 创建模板
+// 参数:
+//   - ctx: 上下文
+//   - req: 创建模板DO对象
+//
+// 返回: 模板ID和错误信息
+// 业务逻辑: 默认启用,记录操作人
+// 调用链: service.CreateSmsTemplate -> repo.CreateTemplate -> GORM.Create
+func (s *SmsTemplate) CreateTemplate(ctx context.Context, req *do.CreateSmsTemplate) (int64, error) {
+	timeNow := time.Now()
+	qs := query.Use(s.db).SmsTemplate
+	addObj := &model.SmsTemplate{
+		SceneCode:      req.SceneCode,
+		SignName:       req.SignName,
+		PlatformTmplID: req.PlatformTmplID,
+		TmplStr:        req.TmplStr,
+		Platform:       req.Platform,
+		Status:         consts.IsEnable, // 默认启用
+		CreateAt:       timeNow,
+		UpdateAt:       timeNow,
+		AdminUserID:    req.AdminUserID, // 记录操作人
+	}
+	err := qs.WithContext(ctx).Create(addObj)
+	if err != nil {
+		return 0, err
+	}
+	return addObj.ID, nil
+}
+
+// UpdateTemplate 更新模板
+// 参数:
+//   - ctx: 上下文
+//   - req: 更新模板DO对象
+//
+// 返回: 错误信息
+// 可更新字段: 场景编码、签名、平台模板ID、模板内容、平台
+// 调用链: service.UpdateSmsTemplate -> repo.UpdateTemplate -> GORM.Updates
+func (s *SmsTemplate) UpdateTemplate(ctx context.Context, req *do.UpdateSmsTemplate) error {
+	qs := query.Use(s.db).SmsTemplate
+	_, err := qs.WithContext(ctx).Where(qs.ID.Eq(req.ID)).Updates(model.SmsTemplate{
+		SceneCode:      req.SceneCode,
+		SignName:       req.SignName,
+		PlatformTmplID: req.PlatformTmplID,
+		TmplStr:        req.TmplStr,
+		Platform:       req.Platform,
+		UpdateAt:       time.Now(),
+		AdminUserID:    req.AdminUserID, // 记录操作人
+	})
+	return err
+}
+
+// UpdateTemplateStatus 更新模板状态
+// 参数:
+//   - ctx: 上下文
+//   - req: 更新状态DO对象
+//
+// 返回: 错误信息
+// 状态值: consts.IsEnable(1)启用, consts.IsDisable(-1)禁用
+// 调用链: service.UpdateSmsTemplateStatus -> repo.UpdateTemplateStatus -> GORM.Updates
+func (s *SmsTemplate) UpdateTemplateStatus(ctx context.Context, req *do.UpdateSmsTemplateStatus) error {
+	qs := query.Use(s.db).SmsTemplate
+	_, err := qs.WithContext(ctx).Where(qs.ID.Eq(req.ID)).Updates(model.SmsTemplate{
+		Status:      req.Status,
+		UpdateAt:    time.Now(),
+		AdminUserID: req.AdminUserID, // 记录操作人
+	})
+	return err
+}
@@ -1,5 +1,6 @@
 // Package admin 管理员数据访问层
 // 职责: 封装admin_user表的CRUD操作
+// 软删除: 除GetDeletedUser外,所有查询和更新默认排除已删除的管理员
 // 调用链: service -> repo -> GORM
 package admin
 
@@ -10,6 +11,7 @@ import (
 	"mall/adaptor/repo/query"
 	"mall/consts"
 	"mall/service/do"
+	"mall/utils/tools"
 	"time"
 
 	"github.com/go-redis/redis"
@@ -18,11 +20,16 @@ import (
 
 // IAdminUser 管理员用户数据访问接口
 type IAdminUser interface {
-	CreateUser(ctx context.Context, req *do.CreateUser) (int64, error)        // 创建管理员
-	UpdateUser(ctx context.Context, req *do.UpdateUser) error                 // 更新管理员信息
-	UpdateUserStatus(ctx context.Context, req *do.UpdateUserStatus) error     // 更新管理员状态(启用/禁用)
-	UpdateUserPassword(ctx context.Context, req *do.UpdateUserPassword) error // 更新管理员密码
-	GetUserInfo(ctx context.Context, userId int64) (*model.AdminUser, error)  // 获取管理员详细信息
+	CreateUser(ctx context.Context, req *do.CreateUser) (int64, error)                  // 创建管理员
+	UpdateUser(ctx context.Context, req *do.UpdateUser) error                           // 更新管理员信息
+	UpdateUserStatus(ctx context.Context, req *do.UpdateUserStatus) error               // 更新管理员状态(启用/禁用)
+	UpdateUserPassword(ctx context.Context, req *do.UpdateUserPassword) error           // 更新管理员密码
+	GetUserInfo(ctx context.Context, userId int64) (*model.AdminUser, error)            // 获取管理员详细信息
+	GetUserByMobile(ctx context.Context, mobile string) (*model.AdminUser, error)       // 根据手机号获取管理员
+	ListUsers(ctx context.Context, req *do.ListUser) ([]*model.AdminUser, int64, error) // 分页查询管理员列表
+	DeleteUser(ctx context.Context, req *do.DeleteUser) error                           // 软删除管理员
+	RestoreUser(ctx context.Context, req *do.RestoreUser) error                         // 恢复已删除的管理员
+	GetDeletedUser(ctx context.Context, userId int64) (*model.AdminUser, error)         // 获取已删除的管理员
 }
 
 // AdminUser 管理员用户数据访问实现
@@ -86,7 +93,7 @@ func (a *AdminUser) CreateUser(ctx context.Context, req *do.CreateUser) (int64,
 // 调用链: service.UpdateUser -> repo.UpdateUser -> GORM.Updates
 func (a *AdminUser) UpdateUser(ctx context.Context, req *do.UpdateUser) error {
 	qs := query.Use(a.db).AdminUser
-	_, err := qs.WithContext(ctx).Where(qs.ID.Eq(req.ID)).Updates(model.AdminUser{
+	_, err := qs.WithContext(ctx).Where(qs.ID.Eq(req.ID), qs.IsDelete.Eq(consts.NotDeleted)).Updates(model.AdminUser{
 		Name:     req.Name,
 		NickName: req.NickName,
 		Sex:      req.Sex,
@@ -107,18 +114,23 @@ func (a *AdminUser) UpdateUser(ctx context.Context, req *do.UpdateUser) error {
 // 返回: 错误信息
 // 状态值: consts.IsEnable(1)启用, consts.IsDisable(-1)禁用
 // 用途: 管理员账号的启用/停用管理
-// 调用链: service.UpdateUserStatus -> repo.UpdateUserStatus -> GORM.Updates
+// 事务: 禁用时在同一事务中锁定并校验启用中的超级管理员,目标是最后一个时返回ErrLastSuperAdmin
+// 调用链: service.UpdateUserStatus -> repo.UpdateUserStatus -> GORM.Transaction
 func (a *AdminUser) UpdateUserStatus(ctx context.Context, req *do.UpdateUserStatus) error {
-	qs := query.Use(a.db).AdminUser
-	_, err := qs.WithContext(ctx).Where(qs.ID.Eq(req.ID)).Updates(model.AdminUser{
-		Status:   req.Status,
-		UpdateAt: time.Now(),
-		UpdateBy: req.AdminUserID, // 记录更新人
-	})
-	if err != nil {
+	return query.Use(a.db).Transaction(func(tx *query.Query) error {
+		if req.Status == consts.IsDisable {
+			if err := checkLastSuperAdmin(ctx, tx, req.ID); err != nil {
+				return err
+			}
+		}
+		qs := tx.AdminUser
+		_, err := qs.WithContext(ctx).Where(qs.ID.Eq(req.ID), qs.IsDelete.Eq(consts.NotDeleted)).Updates(model.AdminUser{
+			Status:   req.Status,
+			UpdateAt: time.Now(),
+			UpdateBy: req.AdminUserID, // 记录更新人
+		})
 		return err
-	}
-	return nil
+	})
 }
 
 // UpdateUserPassword 更新管理员用户密码
@@ -127,12 +139,14 @@ func (a *AdminUser) UpdateUserStatus(ctx context.Context, req *do.UpdateUserStat
 //   - req: 更新密码请求DO对象
 //
 // 返回: 错误信息
-// 注意: 传入的password应该已经是SHA256哈希后的值
-// 调用链: service.ResetPassword -> repo.UpdateUserPassword -> GORM.Updates
+// 注意: 传入的password应该已经是加盐哈希后的值
+// 调用链: service.ResetPassword/ChangePassword -> repo.UpdateUserPassword -> GORM.Updates
 func (a *AdminUser) UpdateUserPassword(ctx context.Context, req *do.UpdateUserPassword) error {
 	qs := query.Use(a.db).AdminUser
-	_, err := qs.WithContext(ctx).Where(qs.ID.Eq(req.ID)).Updates(model.AdminUser{
+	_, err := qs.WithContext(ctx).Where(qs.ID.Eq(req.ID), qs.IsDelete.Eq(consts.NotDeleted)).Updates(model.AdminUser{
 		Password: req.Password, // 哈希后的密码
+		UpdateAt: time.Now(),
+		UpdateBy: req.AdminUserID, // 记录更新人
 	})
 	if err != nil {
 		return err
@@ -147,8 +161,120 @@ func (a *AdminUser) UpdateUserPassword(ctx context.Context, req *do.UpdateUserPa
 //
 // 返回: 用户对象和错误信息
 // 用途: 获取管理员个人资料、权限查询等
+// 过滤: 已删除的管理员返回gorm.ErrRecordNotFound
 // 调用链: service.GetUserInfo -> repo.GetUserInfo -> GORM.First
 func (a *AdminUser) GetUserInfo(ctx context.Context, userId int64) (*model.AdminUser, error) {
 	qs := query.Use(a.db).AdminUser
-	return qs.WithContext(ctx).Where(qs.ID.Eq(userId)).First()
+	return qs.WithContext(ctx).Where(qs.ID.Eq(userId), qs.IsDelete.Eq(consts.NotDeleted)).First()
+}
+
+// GetUserByMobile 根据手机号获取管理员
+// 参数:
+//   - ctx: 上下文
+//   - mobile: 手机号
+//
+// 返回: 用户对象和错误信息,不存在返回gorm.ErrRecordNotFound
+// 过滤: 已删除的管理员不参与登录
+// 调用链: service.MobilePasswordLogin -> repo.GetUserByMobile -> GORM.First
+func (a *AdminUser) GetUserByMobile(ctx context.Context, mobile string) (*model.AdminUser, error) {
+	qs := query.Use(a.db).AdminUser
+	return qs.WithContext(ctx).Where(qs.Mobile.Eq(mobile), qs.IsDelete.Eq(consts.NotDeleted)).First()
+}
+
+// ListUsers 分页查询管理员列表
+// 参数:
+//   - ctx: 上下文
+//   - req: 查询条件DO对象
+//
+// 返回: 当前页管理员列表、总条数和错误信息
+// 过滤: 姓名/昵称模糊匹配、手机号、状态、性别、创建时间范围,已删除的管理员不返回
+// 排序: 按创建时间或更新时间,相同时按ID保证分页稳定
+// 调用链: service.ListUsers -> repo.ListUsers -> GORM.FindByPage
+func (a *AdminUser) ListUsers(ctx context.Context, req *do.ListUser) ([]*model.AdminUser, int64, error) {
+	qs := query.Use(a.db).AdminUser
+	dao := qs.WithContext(ctx).Where(qs.IsDelete.Eq(consts.NotDeleted))
+	if req.Name != "" {
+		keyword := "%" + tools.EscapeLike(req.Name) + "%"
+		dao = dao.Where(qs.WithContext(ctx).Where(qs.Name.Like(keyword)).Or(qs.NickName.Like(keyword)))
+	}
+	if req.Mobile != "" {
+		dao = dao.Where(qs.Mobile.Eq(req.Mobile))
+	}
+	if req.Status != 0 {
+		dao = dao.Where(qs.Status.Eq(req.Status))
+	}
+	if req.Sex != 0 {
+		dao = dao.Where(qs.Sex.Eq(req.Sex))
+	}
+	if !req.CreateStart.IsZero() {
+		dao = dao.Where(qs.CreateAt.Gte(req.CreateStart))
+	}
+	if !req.CreateEnd.IsZero() {
+		dao = dao.Where(qs.CreateAt.Lte(req.CreateEnd))
+	}
+
+	orderCol := qs.CreateAt
+	if req.OrderBy == "update_at" {
+		orderCol = qs.UpdateAt
+	}
+	if req.Desc {
+		dao = dao.Order(orderCol.Desc(), qs.ID.Desc())
+	} else {
+		dao = dao.Order(orderCol, qs.ID)
+	}
+	return dao.FindByPage(req.Offset, req.Limit)
+}
+
+// DeleteUser 软删除管理员
+// 参数:
+//   - ctx: 上下文
+//   - req: 删除请求DO对象
+//
+// 返回: 错误信息
+// 实现: 标记is_delete,保留数据便于审计和恢复
+// 事务: 在同一事务中锁定并校验启用中的超级管理员,目标是最后一个时返回ErrLastSuperAdmin
+// 调用链: service.DeleteUser -> repo.DeleteUser -> GORM.Transaction
+func (a *AdminUser) DeleteUser(ctx context.Context, req *do.DeleteUser) error {
+	return query.Use(a.db).Transaction(func(tx *query.Query) error {
+		if err := checkLastSuperAdmin(ctx, tx, req.ID); err != nil {
+			return err
+		}
+		qs := tx.AdminUser
+		_, err := qs.WithContext(ctx).Where(qs.ID.Eq(req.ID), qs.IsDelete.Eq(consts.NotDeleted)).UpdateSimple(
+			qs.IsDelete.Value(consts.IsDeleted),
+			qs.UpdateAt.Value(time.Now()),
+			qs.UpdateBy.Value(req.AdminUserID), // 记录更新人
+		)
+		return err
+	})
+}
+
+// RestoreUser 恢复已删除的管理员
+// 参数:
+//   - ctx: 上下文
+//   - req: 恢复请求DO对象
+//
+// 返回: 错误信息
+// 注意: is_delete恢复为0是零值,需使用UpdateSimple而非Updates(struct)
+// 调用链: service.RestoreUser -> repo.RestoreUser -> GORM.UpdateSimple
+func (a *AdminUser) RestoreUser(ctx context.Context, req *do.RestoreUser) error {
+	qs := query.Use(a.db).AdminUser
+	_, err := qs.WithContext(ctx).Where(qs.ID.Eq(req.ID), qs.IsDelete.Eq(consts.IsDeleted)).UpdateSimple(
+		qs.IsDelete.Value(consts.NotDeleted),
+		qs.UpdateAt.Value(time.Now()),
+		qs.UpdateBy.Value(req.AdminUserID), // 记录更新人
+	)
+	return err
+}
+
+// GetDeletedUser 获取已删除的管理员
+// 参数:
+//   - ctx: 上下文
+//   - userId: 用户ID
+//
+// 返回: 用户对象和错误信息,不存在或未删除返回gorm.ErrRecordNotFound
+// 调用链: service.RestoreUser -> repo.GetDeletedUser -> GORM.First
+func (a *AdminUser) GetDeletedUser(ctx context.Context, userId int64) (*model.AdminUser, error) {
+	qs := query.Use(a.db).AdminUser
+	return qs.WithContext(ctx).Where(qs.ID.Eq(userId), qs.IsDelete.Eq(consts.IsDeleted)).First()
 }
@@ -0,0 +1,199 @@
+// Package admin 管理员数据访问层-管理员角色
+// 职责: 封装admin_user_role表的查询和整体替换操作
+// 调用链: service -> repo -> GORM
+package admin
+
+import (
+	"context"
+	"errors"
+	"mall/adaptor"
+	"mall/adaptor/repo/model"
+	"mall/adaptor/repo/query"
+	"mall/consts"
+	"mall/service/do"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IAdminUserRole 管理员角色关联数据访问接口
+type IAdminUserRole interface {
+	HasRole(ctx context.Context, userID, roleID int64) (bool, error)             // 判断管理员是否拥有指定角色
+	ListRoleIDsByUser(ctx context.Context, userID int64) ([]int64, error)        // 查询管理员分配的角色ID
+	ListEnabledRoleIDsByUser(ctx context.Context, userID int64) ([]int64, error) // 查询管理员分配的启用中角色ID
+	ReplaceUserRoles(ctx context.Context, req *do.ReplaceUserRole) error         // 整体替换管理员的角色
+}
+
+// ErrLastSuperAdmin 操作会使系统中没有启用中的超级管理员
+// 由禁用、删除管理员及替换管理员角色在事务内检查后返回
+var ErrLastSuperAdmin = errors.New("admin: last enabled super admin")
+
+// AdminUserRole 管理员角色关联数据访问实现
+type AdminUserRole struct {
+	db *gorm.DB // 数据库连接
+}
+
+// NewAdminUserRole 创建管理员角色关联数据访问实例
+// 参数: adaptor 适配器,提供数据库连接
+// 返回: AdminUserRole实例
+// 调用链: service.NewService -> NewAdminUserRole
+func NewAdminUserRole(adaptor adaptor.IAdaptor) *AdminUserRole {
+	return &AdminUserRole{
+		db: adaptor.GetDB(),
+	}
+}
+
+// HasRole 判断管理员是否拥有指定角色
+// 参数:
+//   - ctx: 上下文
+//   - userID: 管理员ID
+//   - roleID: 角色ID
+//
+// 返回: 是否拥有和错误信息
+// 调用链: service.UpdateUserRoles/RestoreUser -> repo.HasRole -> GORM.Count
+func (a *AdminUserRole) HasRole(ctx context.Context, userID, roleID int64) (bool, error) {
+	qs := query.Use(a.db).AdminUserRole
+	count, err := qs.WithContext(ctx).Where(qs.AdminUserID.Eq(userID), qs.RoleID.Eq(roleID)).Count()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// ListRoleIDsByUser 查询管理员分配的角色ID
+// 参数:
+//   - ctx: 上下文
+//   - userID: 管理员ID
+//
+// 返回: 角色ID列表和错误信息,包含已禁用的角色
+// 调用链: service.GetUserRoles/UpdateUserRoles -> repo.ListRoleIDsByUser -> GORM.Pluck
+func (a *AdminUserRole) ListRoleIDsByUser(ctx context.Context, userID int64) ([]int64, error) {
+	ids := make([]int64, 0)
+	qs := query.Use(a.db).AdminUserRole
+	err := qs.WithContext(ctx).Where(qs.AdminUserID.Eq(userID)).Order(qs.RoleID).Pluck(qs.RoleID, &ids)
+	return ids, err
+}
+
+// ListEnabledRoleIDsByUser 查询管理员分配的启用中角色ID
+// 参数:
+//   - ctx: 上下文
+//   - userID: 管理员ID
+//
+// 返回: 角色ID列表和错误信息
+// 用途: 计算管理员的有效权限,禁用的角色不生效
+// 调用链: service.GetMyPermissions -> repo.ListEnabledRoleIDsByUser -> GORM.Pluck
+func (a *AdminUserRole) ListEnabledRoleIDsByUser(ctx context.Context, userID int64) ([]int64, error) {
+	ids := make([]int64, 0)
+	q := query.Use(a.db)
+	ur, r := q.AdminUserRole, q.Role
+	err := ur.WithContext(ctx).
+		Join(r, r.ID.EqCol(ur.RoleID)).
+		Where(ur.AdminUserID.Eq(userID), r.Status.Eq(consts.IsEnable)).
+		Order(ur.RoleID).
+		Pluck(ur.RoleID, &ids)
+	return ids, err
+}
+
+// ReplaceUserRoles 整体替换管理员的角色
+// 参数:
+//   - ctx: 上下文
+//   - req: 替换请求DO对象,RoleIDs为管理员最终拥有的全部角色
+//
+// 返回: 错误信息
+// 事务: 读取现有关联后按差异删除多余的、新增缺少的,未变化的关联保持原样
+// 超级管理员: 移除超级管理员角色时先锁定启用中的超级管理员,目标是最后一个时返回ErrLastSuperAdmin
+// 角色锁定: 新增关联前锁定新增的角色行,与DeleteRole互斥,角色已被删除时返回gorm.ErrRecordNotFound
+// 注意: admin_user_role表只有更新人和更新时间字段,新增关联时记录为当前操作人
+// 调用链: service.UpdateUserRoles -> repo.ReplaceUserRoles -> GORM.Transaction
+func (a *AdminUserRole) ReplaceUserRoles(ctx context.Context, req *do.ReplaceUserRole) error {
+	timeNow := time.Now()
+	target := make(map[int64]bool, len(req.RoleIDs))
+	for _, id := range req.RoleIDs {
+		target[id] = true
+	}
+	return query.Use(a.db).Transaction(func(tx *query.Query) error {
+		if !target[consts.SuperAdminRoleID] {
+			if err := checkLastSuperAdmin(ctx, tx, req.UserID); err != nil {
+				return err
+			}
+		}
+		ur := tx.AdminUserRole
+		var current []int64
+		if err := ur.WithContext(ctx).Where(ur.AdminUserID.Eq(req.UserID)).Pluck(ur.RoleID, &current); err != nil {
+			return err
+		}
+
+		// 1. 计算差异
+		existed := make(map[int64]bool, len(current))
+		var removed []int64
+		for _, id := range current {
+			existed[id] = true
+			if !target[id] {
+				removed = append(removed, id)
+			}
+		}
+		var added []*model.AdminUserRole
+		var addedIDs []int64
+		for id := range target {
+			if existed[id] {
+				continue
+			}
+			addedIDs = append(addedIDs, id)
+			added = append(added, &model.AdminUserRole{
+				AdminUserID: req.UserID,
+				RoleID:      id,
+				UpdateAt:    timeNow,
+				UpdateBy:    req.AdminUserID, // 记录操作人
+			})
+		}
+
+		// 2. 删除多余的关联
+		if len(removed) > 0 {
+			if _, err := ur.WithContext(ctx).Where(ur.AdminUserID.Eq(req.UserID), ur.RoleID.In(removed...)).Delete(); err != nil {
+				return err
+			}
+		}
+
+		// 3. 锁定新增的角色后新增关联,角色已被并发删除时返回gorm.ErrRecordNotFound
+		if len(added) == 0 {
+			return nil
+		}
+		r := tx.Role
+		count, err := r.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where(r.ID.In(addedIDs...)).Count()
+		if err != nil {
+			return err
+		}
+		if int(count) != len(addedIDs) {
+			return gorm.ErrRecordNotFound
+		}
+		return ur.WithContext(ctx).Create(added...)
+	})
+}
+
+// checkLastSuperAdmin 在事务中校验目标管理员不是最后一个启用中的超级管理员
+// 参数:
+//   - ctx: 上下文
+//   - tx: 事务
+//   - userID: 将被禁用、删除或移除超级管理员角色的管理员ID
+//
+// 返回: 错误信息,是最后一个时返回ErrLastSuperAdmin,不是超级管理员时直接通过
+// 锁定: SELECT ... FOR UPDATE锁定启用中超级管理员的admin_user_role和admin_user行,
+// 并发的禁用、删除、移除角色在提交前阻塞,提交后按最新数据重新判断
+// 调用链: repo.UpdateUserStatus/DeleteUser/ReplaceUserRoles -> checkLastSuperAdmin
+func checkLastSuperAdmin(ctx context.Context, tx *query.Query, userID int64) error {
+	var ids []int64
+	ur, u := tx.AdminUserRole, tx.AdminUser
+	err := ur.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Join(u, u.ID.EqCol(ur.AdminUserID)).
+		Where(ur.RoleID.Eq(consts.SuperAdminRoleID), u.Status.Eq(consts.IsEnable), u.IsDelete.Eq(consts.NotDeleted)).
+		Pluck(ur.AdminUserID, &ids)
+	if err != nil {
+		return err
+	}
+	if len(ids) <= 1 && slices.Contains(ids, userID) {
+		return ErrLastSuperAdmin
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+// Package course 课程数据访问层-课程目录
+// 职责: 封装course_catalog表的操作
+// 结构: 目录为树形结构,ParentID=-1为顶级,通过GoodID关联课程商品
+// 调用链: service -> repo -> GORM
+package course
+
+import (
+	"context"
+	"errors"
+	"mall/adaptor"
+	"mall/adaptor/repo/model"
+	"mall/adaptor/repo/query"
+	"mall/consts"
+	"mall/service/do"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ICourseCatalog 课程目录数据访问接口
+type ICourseCatalog interface {
+	CountCatalogs(ctx context.Context, goodsID int64) (int64, error)                 // 统计课程商品的目录节点数量
+	ListCatalogs(ctx context.Context, goodsID int64) ([]*model.CourseCatalog, error) // 获取课程商品的全部目录节点
+	GetCatalog(ctx context.Context, id int64) (*model.CourseCatalog, error)          // 根据ID获取目录节点
+	CreateCatalog(ctx context.Context, req *do.CreateCatalog) (int64, error)         // 创建目录节点(事务),层级根据加锁读取的父级计算
+	RenameCatalog(ctx context.Context, req *do.RenameCatalog) error                  // 重命名目录节点
+	DeleteCatalog(ctx context.Context, req *do.DeleteCatalog) error                  // 删除没有子目录和课时的目录节点(事务)
+	MoveCatalogs(ctx context.Context, req *do.MoveCatalogs) (int, error)             // 批量移动目录节点(事务),返回更新的节点数
+}
+
+var (
+	ErrCatalogParent = errors.New("course: catalog parent not found") // 父级目录不存在或不属于该课程商品
+	ErrCatalogInUse  = errors.New("course: catalog in use")           // 目录下有子目录或课时
+)
+
+// CourseCatalog 课程目录数据访问实现
+type CourseCatalog struct {
+	db *gorm.DB // 数据库连接
+}
+
+// NewCourseCatalog 创建课程目录数据访问实例
+// 参数: adaptor 适配器,提供数据库连接
+// 返回: CourseCatalog实例
+// 调用链: service.NewService -> NewCourseCatalog
+func NewCourseCatalog(adaptor adaptor.IAdaptor) *CourseCatalog {
+	return &CourseCatalog{
+		db: adaptor.GetDB(),
+	}
+}
+
+// CountCatalogs 统计课程商品的目录节点数量
+// 参数:
+//   - ctx: 上下文
+//   - goodsID: 课程商品ID
+//
+// 返回: 数量和错误信息
+// 用途: 上架前校验课程已有目录
+// 调用链: service.checkGoodsPublishable -> repo.CountCatalogs -> GORM.Count
+func (c *CourseCatalog) CountCatalogs(ctx context.Context, goodsID int64) (int64, error) {
+	qs := query.Use(c.db).CourseCatalog
+	return qs.WithContext(ctx).Where(qs.GoodID.Eq(goodsID)).Count()
+}
+
+// ListCatalogs 获取课程商品的全部目录节点
+// 参数:
+//   - ctx: 上下文
+//   - goodsID: 课程商品ID
+//
+// 返回: 目录节点列表(按Sort、ID升序)和错误信息
+// 调用链: service.GetCatalogTree -> repo.ListCatalogs -> GORM.Find
+func (c *CourseCatalog) ListCatalogs(ctx context.Context, goodsID int64) ([]*model.CourseCatalog, error) {
+	qs := query.Use(c.db).CourseCatalog
+	return qs.WithContext(ctx).Where(qs.GoodID.Eq(goodsID)).Order(qs.Sort, qs.ID).Find()
+}
+
+// GetCatalog 根据ID获取目录节点
+// 参数:
+//   - ctx: 上下文
+//   - id: 目录ID
+//
+// 返回: 目录节点和错误信息,不存在返回gorm.ErrRecordNotFound
+// 调用链: service.getCatalog -> repo.GetCatalog -> GORM.First
+func (c *CourseCatalog) GetCatalog(ctx context.Context, id int64) (*model.CourseCatalog, error) {
+	qs := query.Use(c.db).CourseCatalog
+	return qs.WithContext(ctx).Where(qs.ID.Eq(id)).First()
+}
+
+// CreateCatalog 创建目录节点
+// 参数:
+//   - ctx: 上下文
+//   - req: 创建目录请求DO对象
+//
+// 返回: 目录ID和错误信息,父级不存在或不属于该课程商品返回ErrCatalogParent
+// 特性: 事务内以SELECT ... FOR UPDATE读取课程商品的全部目录,层级根据加锁后的父级计算,
+// 与并发的移动、删除互斥,不会基于过期的父级层级写入,也不会挂到已删除的父级下
+// 调用链: service.CreateCatalog -> repo.CreateCatalog -> GORM.Transaction
+func (c *CourseCatalog) CreateCatalog(ctx context.Context, req *do.CreateCatalog) (int64, error) {
+	timeNow := time.Now()
+	addObj := &model.CourseCatalog{
+		ParentID: req.ParentID,
+		Level:    consts.CatalogRootLevel,
+		Name:     req.Name,
+		GoodID:   req.GoodsID,
+		Sort:     req.Sort,
+		CreateAt: timeNow,
+		UpdateAt: timeNow,
+		UpdateBy: req.AdminUserID, // 记录操作人
+	}
+	err := query.Use(c.db).Transaction(func(tx *query.Query) error {
+		catalogs, err := lockCatalogs(ctx, tx, req.GoodsID)
+		if err != nil {
+			return err
+		}
+		if req.ParentID != consts.CatalogRootParentID {
+			idx := slices.IndexFunc(catalogs, func(catalog *model.CourseCatalog) bool { return catalog.ID == req.ParentID })
+			if idx < 0 {
+				return ErrCatalogParent
+			}
+			addObj.Level = catalogs[idx].Level + 1
+		}
+		return tx.CourseCatalog.WithContext(ctx).Create(addObj)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return addObj.ID, nil
+}
+
+// RenameCatalog 重命名目录节点
+// 参数:
+//   - ctx: 上下文
+//   - req: 重命名请求DO对象
+//
+// 返回: 错误信息
+// 调用链: service.RenameCatalog -> repo.RenameCatalog -> GORM.UpdateSimple
+func (c *CourseCatalog) RenameCatalog(ctx context.Context, req *do.RenameCatalog) error {
+	qs := query.Use(c.db).CourseCatalog
+	_, err := qs.WithContext(ctx).Where(qs.ID.Eq(req.ID)).UpdateSimple(
+		qs.Name.Value(req.Name),
+		qs.UpdateAt.Value(time.Now()),
+		qs.UpdateBy.Value(req.AdminUserID), // 记录操作人
+	)
+	return err
+}
+
+// DeleteCatalog 删除目录节点
+// 参数:
+//   - ctx: 上下文
+//   - req: 删除目录请求DO对象
+//
+// 返回: 错误信息,节点不存在返回gorm.ErrRecordNotFound,有子目录或课时返回ErrCatalogInUse
+// 特性: 事务内以SELECT ... FOR UPDATE读取课程商品的全部目录后统计子目录和课时,
+// 并发新增的子目录在提交前阻塞,提交后找不到父级,不会留下挂在已删除节点下的目录
+// 调用链: service.DeleteCatalog -> repo.DeleteCatalog -> GORM.Transaction
+func (c *CourseCatalog) DeleteCatalog(ctx context.Context, req *do.DeleteCatalog) error {
+	return query.Use(c.db).Transaction(func(tx *query.Query) error {
+		catalogs, err := lockCatalogs(ctx, tx, req.GoodsID)
+		if err != nil {
+			return err
+		}
+		found := false
+		for _, catalog := range catalogs {
+			if catalog.ParentID == req.ID {
+				return ErrCatalogInUse
+			}
+			found = found || catalog.ID == req.ID
+		}
+		if !found {
+			return gorm.ErrRecordNotFound
+		}
+		ql := tx.CourseLesson
+		count, err := ql.WithContext(ctx).Where(ql.CatalogID.Eq(req.ID)).Count()
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrCatalogInUse
+		}
+		qs := tx.CourseCatalog
+		_, err = qs.WithContext(ctx).Where(qs.ID.Eq(req.ID), qs.GoodID.Eq(req.GoodsID)).Delete()
+		return err
+	})
+}
+
+// MoveCatalogs 批量移动目录节点
+// 参数:
+//   - ctx: 上下文
+//   - req: 移动请求DO对象,Plan根据当前目录计算每个节点的新父级、层级和排序
+//
+// 返回: 更新的节点数和错误信息,Plan返回的错误原样返回
+// 特性:
+//   - 事务内以SELECT ... FOR UPDATE读取课程商品的全部目录,并发的移动、新增、删除在提交前阻塞,
+//     Plan基于加锁后的数据校验,不会基于过期的树计算
+//   - 所有节点在同一事务中更新,任一失败全部回滚,不会出现半更新的树
+//
+// 注意: 更新条件带上GoodID,防止误改其他课程的目录
+// 调用链: service.MoveCatalogs -> repo.MoveCatalogs -> GORM.Transaction
+func (c *CourseCatalog) MoveCatalogs(ctx context.Context, req *do.MoveCatalogs) (int, error) {
+	timeNow := time.Now()
+	var updated int
+	err := query.Use(c.db).Transaction(func(tx *query.Query) error {
+		catalogs, err := lockCatalogs(ctx, tx, req.GoodsID)
+		if err != nil {
+			return err
+		}
+		positions, err := req.Plan(catalogs)
+		if err != nil {
+			return err
+		}
+		qs := tx.CourseCatalog
+		for _, pos := range positions {
+			_, err := qs.WithContext(ctx).Where(qs.ID.Eq(pos.ID), qs.GoodID.Eq(req.GoodsID)).UpdateSimple(
+				qs.ParentID.Value(pos.ParentID),
+				qs.Level.Value(pos.Level),
+				qs.Sort.Value(pos.Sort),
+				qs.UpdateAt.Value(timeNow),
+				qs.UpdateBy.Value(req.AdminUserID), // 记录操作人
+			)
+			if err != nil {
+				return err
+			}
+		}
+		updated = len(positions)
+		return nil
+	})
+	return updated, err
+}
+
+// lockCatalogs 在事务中加锁读取课程商品的全部目录
+// 参数:
+//   - ctx: 上下文
+//   - tx: 事务
+//   - goodsID: 课程商品ID
+//
+// 返回: 目录节点列表(按Sort、ID升序)和错误信息
+// 锁定: SELECT ... FOR UPDATE,同一课程商品目录的新增、删除和移动串行执行
+// 调用链: repo.CreateCatalog/DeleteCatalog/MoveCatalogs -> lockCatalogs
+func lockCatalogs(ctx context.Context, tx *query.Query, goodsID int64) ([]*model.CourseCatalog, error) {
+	qs := tx.CourseCatalog
+	return qs.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(qs.GoodID.Eq(goodsID)).Order(qs.Sort, qs.ID).Find()
+}
@@ -0,0 +1,270 @@
+// Package course 课程数据访问层-课程商品
+// 职责: 封装course_goods表的CRUD操作,创建和改价时同步写入course_goods_price价格历史
+// 调用链: service -> repo -> GORM
+package course
+
+import (
+	"context"
+	"mall/adaptor"
+	"mall/adaptor/repo/model"
+	"mall/adaptor/repo/query"
+	"mall/consts"
+	"mall/service/do"
+	"mall/utils/tools"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// effectivePriceSQL 课程商品在计价时间生效的价格,与service.resolveGoodsPrices的规则一致:
+// 免费商品为0,收费商品取生效中且生效时间最晚的价格记录,没有价格记录时取商品标价
+// gen的Where不支持原生SQL条件,通过UnderlyingDB追加
+const effectivePriceSQL = "CASE WHEN course_goods.sale_type = ? THEN 0 ELSE COALESCE((" +
+	"SELECT p.price FROM course_goods_price p WHERE p.goods_id = course_goods.id AND p.effective_from <= ? " +
+	"AND (p.effective_to IS NULL OR p.effective_to > ?) ORDER BY p.effective_from DESC, p.id DESC LIMIT 1" +
+	"), course_goods.course_price) END"
+
+// ICourseGoods 课程商品数据访问接口
+type ICourseGoods interface {
+	ListGoods(ctx context.Context, req *do.ListGoods) ([]*model.CourseGood, int64, error)     // 分页查询课程商品
+	GetGoods(ctx context.Context, id int64) (*model.CourseGood, error)                        // 根据ID获取课程商品
+	CreateGoods(ctx context.Context, req *do.CreateGoods) (int64, error)                      // 创建课程商品
+	UpdateGoods(ctx context.Context, req *do.UpdateGoods) error                               // 更新课程商品
+	UpdateGoodsStatus(ctx context.Context, req *do.UpdateGoodsStatus) error                   // 更新课程商品上下架状态
+	SearchGoods(ctx context.Context, req *do.SearchGoods) ([]*model.CourseGood, int64, error) // 分页查询上架中的课程商品
+	ListGoodsByIDs(ctx context.Context, ids []int64) ([]*model.CourseGood, error)             // 根据ID批量获取课程商品
+}
+
+// CourseGoods 课程商品数据访问实现
+type CourseGoods struct {
+	db *gorm.DB // 数据库连接
+}
+
+// NewCourseGoods 创建课程商品数据访问实例
+// 参数: adaptor 适配器,提供数据库连接
+// 返回: CourseGoods实例
+// 调用链: service.NewService -> NewCourseGoods
+func NewCourseGoods(adaptor adaptor.IAdaptor) *CourseGoods {
+	return &CourseGoods{
+		db: adaptor.GetDB(),
+	}
+}
+
+// ListGoods 分页查询课程商品
+// 参数:
+//   - ctx: 上下文
+//   - req: 查询条件DO对象
+//
+// 返回: 当前页商品列表、总条数和错误信息
+// 过滤: 名称模糊匹配、售卖类型、服务时长、上下架状态
+// 调用链: service.ListGoods -> repo.ListGoods -> GORM.FindByPage
+func (c *CourseGoods) ListGoods(ctx context.Context, req *do.ListGoods) ([]*model.CourseGood, int64, error) {
+	qs := query.Use(c.db).CourseGood
+	q := qs.WithContext(ctx)
+	if req.Name != "" {
+		q = q.Where(qs.Name.Like("%" + tools.EscapeLike(req.Name) + "%"))
+	}
+	if req.SaleType != 0 {
+		q = q.Where(qs.SaleType.Eq(req.SaleType))
+	}
+	if req.ServiceTime != 0 {
+		q = q.Where(qs.ServiceTime.Eq(req.ServiceTime))
+	}
+	if req.Status != 0 {
+		q = q.Where(qs.Status.Eq(req.Status))
+	}
+	return q.Order(qs.ID.Desc()).FindByPage(req.Offset, req.Limit)
+}
+
+// GetGoods 根据ID获取课程商品
+// 参数:
+//   - ctx: 上下文
+//   - id: 商品ID
+//
+// 返回: 商品对象和错误信息,不存在返回gorm.ErrRecordNotFound
+// 调用链: service.GetGoods/UpdateGoods -> repo.GetGoods -> GORM.First
+func (c *CourseGoods) GetGoods(ctx context.Context, id int64) (*model.CourseGood, error) {
+	qs := query.Use(c.db).CourseGood
+	return qs.WithContext(ctx).Where(qs.ID.Eq(id)).First()
+}
+
+// CreateGoods 创建课程商品
+// 参数:
+//   - ctx: 上下文
+//   - req: 创建商品请求DO对象
+//
+// 返回: 商品ID和错误信息
+// 业务逻辑: 默认下架,记录创建人和更新人,同一事务中写入初始价格记录
+// 调用链: service.CreateGoods -> repo.CreateGoods -> GORM.Transaction
+func (c *CourseGoods) CreateGoods(ctx context.Context, req *do.CreateGoods) (int64, error) {
+	timeNow := time.Now()
+	addObj := &model.CourseGood{
+		Name:           req.Name,
+		CoverKey:       req.CoverKey,
+		DetailCoverKey: req.DetailCoverKey,
+		Detail:         req.Detail,
+		CoursePrice:    req.CoursePrice,
+		ServiceTime:    req.ServiceTime,
+		SaleType:       req.SaleType,
+		Status:         consts.GoodsOffShelf, // 默认下架,完善目录和课时后再上架
+		Features:       req.Features,
+		CreateAt:       timeNow,
+		CreateBy:       req.AdminUserID, // 记录创建人
+		UpdateAt:       timeNow,
+		UpdateBy:       req.AdminUserID,
+	}
+	err := query.Use(c.db).Transaction(func(tx *query.Query) error {
+		if err := tx.CourseGood.WithContext(ctx).Create(addObj); err != nil {
+			return err
+		}
+		return tx.CourseGoodsPrice.WithContext(ctx).Create(&model.CourseGoodsPrice{
+			GoodsID:       addObj.ID,
+			Price:         req.CoursePrice,
+			EffectiveFrom: timeNow,
+			CreateAt:      timeNow,
+			CreateBy:      req.AdminUserID,
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+	return addObj.ID, nil
+}
+
+// UpdateGoods 更新课程商品
+// 参数:
+//   - ctx: 上下文
+//   - req: 更新商品请求DO对象
+//
+// 返回: 错误信息
+// 可更新字段: 上下架状态以外的全部业务字段(允许清空或置0)
+// 价格历史: 价格有变化时同一事务中写入一条立即生效的价格记录
+// 调用链: service.UpdateGoods -> repo.UpdateGoods -> GORM.Transaction
+func (c *CourseGoods) UpdateGoods(ctx context.Context, req *do.UpdateGoods) error {
+	timeNow := time.Now()
+	return query.Use(c.db).Transaction(func(tx *query.Query) error {
+		qs := tx.CourseGood
+		_, err := qs.WithContext(ctx).Where(qs.ID.Eq(req.ID)).UpdateSimple(
+			qs.Name.Value(req.Name),
+			qs.CoverKey.Value(req.CoverKey),
+			qs.DetailCoverKey.Value(req.DetailCoverKey),
+			qs.Detail.Value(req.Detail),
+			qs.CoursePrice.Value(req.CoursePrice),
+			qs.ServiceTime.Value(req.ServiceTime),
+			qs.SaleType.Value(req.SaleType),
+			qs.Features.Value(req.Features),
+			qs.UpdateAt.Value(timeNow),
+			qs.UpdateBy.Value(req.AdminUserID), // 记录更新人
+		)
+		if err != nil || !req.PriceChanged {
+			return err
+		}
+		return tx.CourseGoodsPrice.WithContext(ctx).Create(&model.CourseGoodsPrice{
+			GoodsID:       req.ID,
+			Price:         req.CoursePrice,
+			EffectiveFrom: timeNow,
+			CreateAt:      timeNow,
+			CreateBy:      req.AdminUserID,
+		})
+	})
+}
+
+// UpdateGoodsStatus 更新课程商品上下架状态
+// 参数:
+//   - ctx: 上下文
+//   - req: 更新状态请求DO对象
+//
+// 返回: 错误信息,商品不存在返回gorm.ErrRecordNotFound,Check返回的错误原样返回
+// 状态值: consts.GoodsOnShelf(1)上架, consts.GoodsOffShelf(-1)下架
+// 记录: 操作人写入UpdateBy,操作时间写入UpdateAt,同一事务中写入course_goods_status_log
+// 并发:
+//   - 事务内以SELECT ... FOR UPDATE锁定商品行,状态已不是FromStatus时不更新也不写记录
+//   - 上架时在锁内统计目录和课时后调用Check,与同样锁定商品行的移除课时(DeleteLesson)互斥
+//
+// 注意: 只修改商品状态,不影响user_course_goods中已购用户的权益
+// 调用链: service.UpdateGoodsStatus -> repo.UpdateGoodsStatus -> GORM.Transaction
+func (c *CourseGoods) UpdateGoodsStatus(ctx context.Context, req *do.UpdateGoodsStatus) error {
+	timeNow := time.Now()
+	return query.Use(c.db).Transaction(func(tx *query.Query) error {
+		qs := tx.CourseGood
+		g, err := qs.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where(qs.ID.Eq(req.ID)).Take()
+		if err != nil {
+			return err
+		}
+		if g.Status != req.FromStatus {
+			return nil
+		}
+		if req.Check != nil {
+			qc, ql := tx.CourseCatalog, tx.CourseLesson
+			catalogs, err := qc.WithContext(ctx).Where(qc.GoodID.Eq(req.ID)).Count()
+			if err != nil {
+				return err
+			}
+			lessons, err := ql.WithContext(ctx).Where(ql.CourseGoodsID.Eq(req.ID)).Count()
+			if err != nil {
+				return err
+			}
+			if err = req.Check(g, catalogs, lessons); err != nil {
+				return err
+			}
+		}
+		_, err = qs.WithContext(ctx).Where(qs.ID.Eq(req.ID)).Updates(model.CourseGood{
+			Status:   req.Status,
+			UpdateAt: timeNow,
+			UpdateBy: req.AdminUserID, // 记录操作人
+		})
+		if err != nil {
+			return err
+		}
+		return tx.CourseGoodsStatusLog.WithContext(ctx).Create(&model.CourseGoodsStatusLog{
+			GoodsID:    req.ID,
+			FromStatus: req.FromStatus,
+			ToStatus:   req.Status,
+			CreateAt:   timeNow,
+			CreateBy:   req.AdminUserID,
+		})
+	})
+}
+
+// SearchGoods 分页查询上架中的课程商品
+// 参数:
+//   - ctx: 上下文
+//   - req: 查询条件DO对象
+//
+// 返回: 当前页商品列表、总条数和错误信息
+// 过滤: 只返回上架商品,关键字匹配名称、特色或详情,售卖类型,价格区间(闭区间,按req.Now生效的价格过滤)
+// 调用链: user.SearchGoods -> repo.SearchGoods -> GORM.FindByPage
+func (c *CourseGoods) SearchGoods(ctx context.Context, req *do.SearchGoods) ([]*model.CourseGood, int64, error) {
+	qs := query.Use(c.db).CourseGood
+	q := qs.WithContext(ctx).Where(qs.Status.Eq(consts.GoodsOnShelf))
+	if req.Keyword != "" {
+		keyword := "%" + tools.EscapeLike(req.Keyword) + "%"
+		q = q.Where(qs.WithContext(ctx).Where(qs.Name.Like(keyword)).Or(qs.Features.Like(keyword)).Or(qs.Detail.Like(keyword)))
+	}
+	if req.SaleType != 0 {
+		q = q.Where(qs.SaleType.Eq(req.SaleType))
+	}
+	if req.MinPrice > 0 {
+		q.ReplaceDB(q.UnderlyingDB().Where("("+effectivePriceSQL+") >= ?", consts.SaleTypeFree, req.Now, req.Now, req.MinPrice))
+	}
+	if req.MaxPrice > 0 {
+		q.ReplaceDB(q.UnderlyingDB().Where("("+effectivePriceSQL+") <= ?", consts.SaleTypeFree, req.Now, req.Now, req.MaxPrice))
+	}
+	return q.Order(qs.ID.Desc()).FindByPage(req.Offset, req.Limit)
+}
+
+// ListGoodsByIDs 根据ID批量获取课程商品
+// 参数:
+//   - ctx: 上下文
+//   - ids: 商品ID列表
+//
+// 返回: 商品列表和错误信息,不存在的ID不返回
+// 调用链: user.PlaceOrder -> repo.ListGoodsByIDs -> GORM.Find
+func (c *CourseGoods) ListGoodsByIDs(ctx context.Context, ids []int64) ([]*model.CourseGood, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	qs := query.Use(c.db).CourseGood
+	return qs.WithContext(ctx).Where(qs.ID.In(ids...)).Find()
+}
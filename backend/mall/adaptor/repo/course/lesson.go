@@ -0,0 +1,235 @@
+// Package course 课程数据访问层-课程课时
+// 职责: 封装course_lessons表的操作
+// 结构: 课时挂在目录节点下,通过CourseGoodsID关联课程商品,LessonID为录播课时ID
+// 调用链: service -> repo -> GORM
+package course
+
+import (
+	"context"
+	"errors"
+	"mall/adaptor"
+	"mall/adaptor/repo/model"
+	"mall/adaptor/repo/query"
+	"mall/consts"
+	"mall/service/do"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ICourseLesson 课程课时数据访问接口
+type ICourseLesson interface {
+	CountLessons(ctx context.Context, goodsID int64) (int64, error)                                      // 统计课程商品的课时数量
+	CountLessonsByLessonID(ctx context.Context, goodsID, lessonID int64) (int64, error)                  // 统计课程商品下挂载指定录播课时的数量
+	ListLessons(ctx context.Context, goodsID int64) ([]*model.CourseLesson, error)                       // 获取课程商品的全部课时
+	ListVisibleLessons(ctx context.Context, goodsID int64, now time.Time) ([]*model.CourseLesson, error) // 获取课程商品已到可见时间的课时
+	GetLesson(ctx context.Context, id int64) (*model.CourseLesson, error)                                // 根据ID获取课时
+	CreateLesson(ctx context.Context, req *do.CreateLesson) (int64, error)                               // 挂载课时
+	DeleteLesson(ctx context.Context, req *do.DeleteLesson) error                                        // 移除课时(事务),上架中的课程商品不能移除最后一个课时
+	SortLessons(ctx context.Context, req *do.SortLessons) error                                          // 批量调整课时目录和排序(事务)
+	UpdateLessonTrial(ctx context.Context, req *do.UpdateLessonTrial) error                              // 更新课时试听标记
+	UpdateLessonShowTime(ctx context.Context, req *do.UpdateLessonShowTime) error                        // 更新课时可见时间
+}
+
+// ErrLastLesson 上架中的课程商品只剩一个课时,不能移除
+var ErrLastLesson = errors.New("course: last lesson of an on-shelf goods")
+
+// CourseLesson 课程课时数据访问实现
+type CourseLesson struct {
+	db *gorm.DB // 数据库连接
+}
+
+// NewCourseLesson 创建课程课时数据访问实例
+// 参数: adaptor 适配器,提供数据库连接
+// 返回: CourseLesson实例
+// 调用链: service.NewService -> NewCourseLesson
+func NewCourseLesson(adaptor adaptor.IAdaptor) *CourseLesson {
+	return &CourseLesson{
+		db: adaptor.GetDB(),
+	}
+}
+
+// CountLessons 统计课程商品的课时数量
+// 参数:
+//   - ctx: 上下文
+//   - goodsID: 课程商品ID
+//
+// 返回: 数量和错误信息
+// 用途: 上架前校验课程至少有一个课时
+// 调用链: service.checkGoodsPublishable -> repo.CountLessons -> GORM.Count
+func (c *CourseLesson) CountLessons(ctx context.Context, goodsID int64) (int64, error) {
+	qs := query.Use(c.db).CourseLesson
+	return qs.WithContext(ctx).Where(qs.CourseGoodsID.Eq(goodsID)).Count()
+}
+
+// CountLessonsByLessonID 统计课程商品下挂载指定录播课时的数量
+// 参数:
+//   - ctx: 上下文
+//   - goodsID: 课程商品ID
+//   - lessonID: 录播课时ID
+//
+// 返回: 数量和错误信息
+// 用途: 防止同一录播课时在同一课程中重复挂载
+// 调用链: service.AttachLesson -> repo.CountLessonsByLessonID -> GORM.Count
+func (c *CourseLesson) CountLessonsByLessonID(ctx context.Context, goodsID, lessonID int64) (int64, error) {
+	qs := query.Use(c.db).CourseLesson
+	return qs.WithContext(ctx).Where(qs.CourseGoodsID.Eq(goodsID), qs.LessonID.Eq(lessonID)).Count()
+}
+
+// ListLessons 获取课程商品的全部课时
+// 参数:
+//   - ctx: 上下文
+//   - goodsID: 课程商品ID
+//
+// 返回: 课时列表(按Sort、ID升序)和错误信息,包含未到可见时间的课时
+// 调用链: service.ListLessons/SortLessons -> repo.ListLessons -> GORM.Find
+func (c *CourseLesson) ListLessons(ctx context.Context, goodsID int64) ([]*model.CourseLesson, error) {
+	qs := query.Use(c.db).CourseLesson
+	return qs.WithContext(ctx).Where(qs.CourseGoodsID.Eq(goodsID)).Order(qs.Sort, qs.ID).Find()
+}
+
+// ListVisibleLessons 获取课程商品已到可见时间的课时
+// 参数:
+//   - ctx: 上下文
+//   - goodsID: 课程商品ID
+//   - now: 当前时间,ShowTime晚于该时间的课时不返回
+//
+// 返回: 课时列表(按Sort、ID升序)和错误信息
+// 调用链: user.ListCourseLessons -> repo.ListVisibleLessons -> GORM.Find
+func (c *CourseLesson) ListVisibleLessons(ctx context.Context, goodsID int64, now time.Time) ([]*model.CourseLesson, error) {
+	qs := query.Use(c.db).CourseLesson
+	return qs.WithContext(ctx).Where(qs.CourseGoodsID.Eq(goodsID), qs.ShowTime.Lte(now)).Order(qs.Sort, qs.ID).Find()
+}
+
+// GetLesson 根据ID获取课时
+// 参数:
+//   - ctx: 上下文
+//   - id: 课时记录ID
+//
+// 返回: 课时对象和错误信息,不存在返回gorm.ErrRecordNotFound
+// 调用链: service.getLesson -> repo.GetLesson -> GORM.First
+func (c *CourseLesson) GetLesson(ctx context.Context, id int64) (*model.CourseLesson, error) {
+	qs := query.Use(c.db).CourseLesson
+	return qs.WithContext(ctx).Where(qs.ID.Eq(id)).First()
+}
+
+// CreateLesson 挂载课时
+// 参数:
+//   - ctx: 上下文
+//   - req: 挂载课时请求DO对象
+//
+// 返回: 课时记录ID和错误信息
+// 调用链: service.AttachLesson -> repo.CreateLesson -> GORM.Create
+func (c *CourseLesson) CreateLesson(ctx context.Context, req *do.CreateLesson) (int64, error) {
+	qs := query.Use(c.db).CourseLesson
+	addObj := &model.CourseLesson{
+		CourseGoodsID: req.GoodsID,
+		CatalogID:     req.CatalogID,
+		LessonID:      req.LessonID,
+		EnableTrial:   req.EnableTrial,
+		Sort:          req.Sort,
+		ShowTime:      req.ShowTime,
+		UpdateAt:      time.Now(),
+		UpdateBy:      req.AdminUserID, // 记录操作人
+	}
+	err := qs.WithContext(ctx).Create(addObj)
+	if err != nil {
+		return 0, err
+	}
+	return addObj.ID, nil
+}
+
+// DeleteLesson 移除课时
+// 参数:
+//   - ctx: 上下文
+//   - req: 移除课时请求DO对象
+//
+// 返回: 错误信息,商品不存在返回gorm.ErrRecordNotFound,上架中的课程商品只剩这一个课时返回ErrLastLesson
+// 并发: 事务内以SELECT ... FOR UPDATE锁定商品行后统计课时,与上架(UpdateGoodsStatus)及其他移除互斥
+// 注意: 只解除与课程的关联,不影响录播课时本身
+// 调用链: service.DetachLesson -> repo.DeleteLesson -> GORM.Transaction
+func (c *CourseLesson) DeleteLesson(ctx context.Context, req *do.DeleteLesson) error {
+	return query.Use(c.db).Transaction(func(tx *query.Query) error {
+		qg := tx.CourseGood
+		g, err := qg.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Select(qg.ID, qg.Status).Where(qg.ID.Eq(req.GoodsID)).Take()
+		if err != nil {
+			return err
+		}
+		qs := tx.CourseLesson
+		if g.Status == consts.GoodsOnShelf {
+			count, err := qs.WithContext(ctx).Where(qs.CourseGoodsID.Eq(req.GoodsID)).Count()
+			if err != nil {
+				return err
+			}
+			if count <= 1 {
+				return ErrLastLesson
+			}
+		}
+		_, err = qs.WithContext(ctx).Where(qs.ID.Eq(req.ID), qs.CourseGoodsID.Eq(req.GoodsID)).Delete()
+		return err
+	})
+}
+
+// SortLessons 批量调整课时目录和排序
+// 参数:
+//   - ctx: 上下文
+//   - req: 排序请求DO对象,包含每个课时的新目录和排序
+//
+// 返回: 错误信息
+// 特性: 所有课时在同一事务中更新,任一失败全部回滚
+// 注意: 更新条件带上CourseGoodsID,防止误改其他课程的课时
+// 调用链: service.SortLessons -> repo.SortLessons -> GORM.Transaction
+func (c *CourseLesson) SortLessons(ctx context.Context, req *do.SortLessons) error {
+	timeNow := time.Now()
+	return query.Use(c.db).Transaction(func(tx *query.Query) error {
+		qs := tx.CourseLesson
+		for _, pos := range req.Positions {
+			_, err := qs.WithContext(ctx).Where(qs.ID.Eq(pos.ID), qs.CourseGoodsID.Eq(req.GoodsID)).UpdateSimple(
+				qs.CatalogID.Value(pos.CatalogID),
+				qs.Sort.Value(pos.Sort),
+				qs.UpdateAt.Value(timeNow),
+				qs.UpdateBy.Value(req.AdminUserID), // 记录操作人
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// UpdateLessonTrial 更新课时试听标记
+// 参数:
+//   - ctx: 上下文
+//   - req: 更新试听标记请求DO对象
+//
+// 返回: 错误信息
+// 取值: consts.LessonTrialOn(1)可试听, consts.LessonTrialOff(-1)不可试听
+// 调用链: service.UpdateLessonTrial -> repo.UpdateLessonTrial -> GORM.UpdateSimple
+func (c *CourseLesson) UpdateLessonTrial(ctx context.Context, req *do.UpdateLessonTrial) error {
+	qs := query.Use(c.db).CourseLesson
+	_, err := qs.WithContext(ctx).Where(qs.ID.Eq(req.ID)).UpdateSimple(
+		qs.EnableTrial.Value(req.EnableTrial),
+		qs.UpdateAt.Value(time.Now()),
+		qs.UpdateBy.Value(req.AdminUserID), // 记录操作人
+	)
+	return err
+}
+
+// UpdateLessonShowTime 更新课时可见时间
+// 参数:
+//   - ctx: 上下文
+//   - req: 更新可见时间请求DO对象
+//
+// 返回: 错误信息
+// 调用链: service.ScheduleLesson -> repo.UpdateLessonShowTime -> GORM.UpdateSimple
+func (c *CourseLesson) UpdateLessonShowTime(ctx context.Context, req *do.UpdateLessonShowTime) error {
+	qs := query.Use(c.db).CourseLesson
+	_, err := qs.WithContext(ctx).Where(qs.ID.Eq(req.ID)).UpdateSimple(
+		qs.ShowTime.Value(req.ShowTime),
+		qs.UpdateAt.Value(time.Now()),
+		qs.UpdateBy.Value(req.AdminUserID), // 记录操作人
+	)
+	return err
+}
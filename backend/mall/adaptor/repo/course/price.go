@@ -0,0 +1,141 @@
+// Package course 课程数据访问层-课程商品价格
+// 职责: 封装course_goods_price表的操作
+// 规则: 价格记录在[EffectiveFrom, EffectiveTo)区间内有效,EffectiveTo为NULL表示长期有效,
+// 同一时刻有多条有效记录时以EffectiveFrom最晚(相同时ID最大)的记录为准,便于限时促销覆盖基础价格
+// 调用链: service -> repo -> GORM
+package course
+
+import (
+	"context"
+	"mall/adaptor"
+	"mall/adaptor/repo/model"
+	"mall/adaptor/repo/query"
+	"mall/service/do"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ICourseGoodsPrice 课程商品价格数据访问接口
+type ICourseGoodsPrice interface {
+	ListPrices(ctx context.Context, goodsID int64) ([]*model.CourseGoodsPrice, error)                            // 获取课程商品的价格历史
+	GetPrice(ctx context.Context, id int64) (*model.CourseGoodsPrice, error)                                     // 根据ID获取价格记录
+	GetEffectivePrice(ctx context.Context, goodsID int64, now time.Time) (*model.CourseGoodsPrice, error)        // 获取课程商品当前生效的价格
+	ListEffectivePrices(ctx context.Context, goodsIDs []int64, now time.Time) ([]*model.CourseGoodsPrice, error) // 批量获取课程商品当前生效的价格
+	CreatePrice(ctx context.Context, req *do.CreateGoodsPrice) (int64, error)                                    // 新增价格记录
+	DeletePrice(ctx context.Context, id int64) error                                                             // 删除价格记录
+}
+
+// CourseGoodsPrice 课程商品价格数据访问实现
+type CourseGoodsPrice struct {
+	db *gorm.DB // 数据库连接
+}
+
+// NewCourseGoodsPrice 创建课程商品价格数据访问实例
+// 参数: adaptor 适配器,提供数据库连接
+// 返回: CourseGoodsPrice实例
+// 调用链: service.NewService -> NewCourseGoodsPrice
+func NewCourseGoodsPrice(adaptor adaptor.IAdaptor) *CourseGoodsPrice {
+	return &CourseGoodsPrice{
+		db: adaptor.GetDB(),
+	}
+}
+
+// ListPrices 获取课程商品的价格历史
+// 参数:
+//   - ctx: 上下文
+//   - goodsID: 课程商品ID
+//
+// 返回: 价格记录列表(按生效时间、ID倒序)和错误信息
+// 调用链: service.ListGoodsPrices -> repo.ListPrices -> GORM.Find
+func (c *CourseGoodsPrice) ListPrices(ctx context.Context, goodsID int64) ([]*model.CourseGoodsPrice, error) {
+	qs := query.Use(c.db).CourseGoodsPrice
+	return qs.WithContext(ctx).Where(qs.GoodsID.Eq(goodsID)).Order(qs.EffectiveFrom.Desc(), qs.ID.Desc()).Find()
+}
+
+// GetPrice 根据ID获取价格记录
+// 参数:
+//   - ctx: 上下文
+//   - id: 价格记录ID
+//
+// 返回: 价格记录和错误信息,不存在返回gorm.ErrRecordNotFound
+// 调用链: service.CancelGoodsPrice -> repo.GetPrice -> GORM.First
+func (c *CourseGoodsPrice) GetPrice(ctx context.Context, id int64) (*model.CourseGoodsPrice, error) {
+	qs := query.Use(c.db).CourseGoodsPrice
+	return qs.WithContext(ctx).Where(qs.ID.Eq(id)).First()
+}
+
+// GetEffectivePrice 获取课程商品当前生效的价格
+// 参数:
+//   - ctx: 上下文
+//   - goodsID: 课程商品ID
+//   - now: 计价时间
+//
+// 返回: 价格记录和错误信息,没有生效的价格记录返回gorm.ErrRecordNotFound
+// 调用链: service.resolveGoodsPrice -> repo.GetEffectivePrice -> GORM.First
+func (c *CourseGoodsPrice) GetEffectivePrice(ctx context.Context, goodsID int64, now time.Time) (*model.CourseGoodsPrice, error) {
+	qs := query.Use(c.db).CourseGoodsPrice
+	return qs.WithContext(ctx).Where(
+		qs.GoodsID.Eq(goodsID),
+		qs.EffectiveFrom.Lte(now),
+		qs.WithContext(ctx).Where(qs.EffectiveTo.IsNull()).Or(qs.EffectiveTo.Gt(now)),
+	).Order(qs.EffectiveFrom.Desc(), qs.ID.Desc()).First()
+}
+
+// ListEffectivePrices 批量获取课程商品当前生效的价格
+// 参数:
+//   - ctx: 上下文
+//   - goodsIDs: 课程商品ID列表
+//   - now: 计价时间
+//
+// 返回: 全部有效的价格记录(同一商品按生效时间、ID倒序,第一条为当前价格)和错误信息
+// 调用链: service.SearchGoods -> repo.ListEffectivePrices -> GORM.Find
+func (c *CourseGoodsPrice) ListEffectivePrices(ctx context.Context, goodsIDs []int64, now time.Time) ([]*model.CourseGoodsPrice, error) {
+	if len(goodsIDs) == 0 {
+		return nil, nil
+	}
+	qs := query.Use(c.db).CourseGoodsPrice
+	return qs.WithContext(ctx).Where(
+		qs.GoodsID.In(goodsIDs...),
+		qs.EffectiveFrom.Lte(now),
+		qs.WithContext(ctx).Where(qs.EffectiveTo.IsNull()).Or(qs.EffectiveTo.Gt(now)),
+	).Order(qs.GoodsID, qs.EffectiveFrom.Desc(), qs.ID.Desc()).Find()
+}
+
+// CreatePrice 新增价格记录
+// 参数:
+//   - ctx: 上下文
+//   - req: 新增价格请求DO对象
+//
+// 返回: 价格记录ID和错误信息
+// 调用链: service.ScheduleGoodsPrice -> repo.CreatePrice -> GORM.Create
+func (c *CourseGoodsPrice) CreatePrice(ctx context.Context, req *do.CreateGoodsPrice) (int64, error) {
+	qs := query.Use(c.db).CourseGoodsPrice
+	addObj := &model.CourseGoodsPrice{
+		GoodsID:       req.GoodsID,
+		Price:         req.Price,
+		EffectiveFrom: req.EffectiveFrom,
+		EffectiveTo:   req.EffectiveTo,
+		CreateAt:      time.Now(),
+		CreateBy:      req.AdminUserID, // 记录创建人
+	}
+	err := qs.WithContext(ctx).Create(addObj)
+	if err != nil {
+		return 0, err
+	}
+	return addObj.ID, nil
+}
+
+// DeletePrice 删除价格记录
+// 参数:
+//   - ctx: 上下文
+//   - id: 价格记录ID
+//
+// 返回: 错误信息
+// 前置: service层已校验只能删除尚未生效的记录,已生效的记录作为历史保留
+// 调用链: service.CancelGoodsPrice -> repo.DeletePrice -> GORM.Delete
+func (c *CourseGoodsPrice) DeletePrice(ctx context.Context, id int64) error {
+	qs := query.Use(c.db).CourseGoodsPrice
+	_, err := qs.WithContext(ctx).Where(qs.ID.Eq(id)).Delete()
+	return err
+}
@@ -0,0 +1,301 @@
+// Package order 订单数据访问层
+// 职责: 封装orders表及order_items表的操作,状态变更时同步写入order_status_log流转记录,订单支付时同步写入user_course_goods
+// 调用链: service -> repo -> GORM
+package order
+
+import (
+	"context"
+	"fmt"
+	"mall/adaptor"
+	"mall/adaptor/repo/model"
+	"mall/adaptor/repo/query"
+	"mall/consts"
+	"mall/service/do"
+	"time"
+
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IOrder 订单数据访问接口
+type IOrder interface {
+	CreateOrder(ctx context.Context, req *do.CreateOrder) (int64, error)                          // 校验重复购买并创建订单及订单商品(事务)
+	GetOrder(ctx context.Context, id int64) (*model.Order, error)                                 // 根据ID获取订单
+	ListTimeoutOrders(ctx context.Context, createBefore int64, limit int) ([]*model.Order, error) // 获取指定时间前创建且仍待支付的订单
+	TransitOrder(ctx context.Context, req *do.TransitOrder) (bool, error)                         // 按原状态更新订单状态并写入流转记录(事务)
+}
+
+// Order 订单数据访问实现
+type Order struct {
+	db *gorm.DB // 数据库连接
+}
+
+// NewOrder 创建订单数据访问实例
+// 参数: adaptor 适配器,提供数据库连接
+// 返回: Order实例
+// 调用链: service.NewService -> NewOrder
+func NewOrder(adaptor adaptor.IAdaptor) *Order {
+	return &Order{
+		db: adaptor.GetDB(),
+	}
+}
+
+// GoodsConflictError 下单的商品已购买或已有待支付订单
+// 由CreateOrder在事务内检查后返回,服务层据此返回对应的错误码
+type GoodsConflictError struct {
+	GoodsID int64 // 冲突的商品ID
+	Pending bool  // true表示已有待支付订单,false表示已购买
+}
+
+// Error 实现error接口
+func (e *GoodsConflictError) Error() string {
+	if e.Pending {
+		return fmt.Sprintf("order: goods %d has a pending order", e.GoodsID)
+	}
+	return fmt.Sprintf("order: goods %d already owned", e.GoodsID)
+}
+
+// ownedStatuses 下单时视为占用商品的订单状态,待支付订单返回Pending冲突,其余视为已购买
+var ownedStatuses = []int32{
+	consts.OrderStatusPending,
+	consts.OrderStatusPaid,
+	consts.OrderStatusShipped,
+	consts.OrderStatusSigned,
+	consts.OrderStatusReceived,
+}
+
+// CreateOrder 创建用户订单及订单商品
+// 参数:
+//   - ctx: 上下文
+//   - req: 创建订单请求DO对象
+//
+// 返回: 订单ID和错误信息,商品已购买或已有待支付订单返回*GoodsConflictError
+// 业务逻辑:
+//  1. 锁定下单用户(SELECT ... FOR UPDATE),同一用户的下单串行执行
+//  2. 校验商品未购买过,且没有包含这些商品的待支付或已支付(含已发货、已签收、已收货)订单
+//  3. 写入订单和订单商品,来源为用户下单,创建人为下单用户
+//  4. AutoPay(0元订单)时订单直接写为已支付,同时写入流转记录和已购课程;否则为待支付
+//
+// 特性: 以上步骤在同一事务中执行,不会出现没有商品的订单,也不会因并发下单重复购买
+// 调用链: service/user.PlaceOrder -> repo.CreateOrder -> GORM.Transaction
+func (o *Order) CreateOrder(ctx context.Context, req *do.CreateOrder) (int64, error) {
+	timeNow := time.Now().UnixMilli()
+	addObj := &model.Order{
+		OrderNo:           req.OrderNo,
+		UserID:            req.UserID,
+		Status:            consts.OrderStatusPending,
+		OrderSource:       consts.OrderSourceUser,
+		OrderAmount:       req.OrderAmount,
+		OrderOriginAmount: req.OrderOriginAmount,
+		OrderDesc:         req.OrderDesc,
+		UserRemark:        req.UserRemark,
+		CreateAt:          timeNow,
+		CreateBy:          req.UserID, // 用户下单,创建人为用户本人
+	}
+	if req.AutoPay {
+		addObj.Status = consts.OrderStatusPaid
+		addObj.PaymentAt = timeNow
+	}
+	goodsIDs := make([]int64, 0, len(req.Items))
+	for _, item := range req.Items {
+		goodsIDs = append(goodsIDs, item.GoodsID)
+	}
+	err := query.Use(o.db).Transaction(func(tx *query.Query) error {
+		// 1. 锁定下单用户
+		qu := tx.User
+		if _, err := qu.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Select(qu.ID).Where(qu.ID.Eq(req.UserID)).Take(); err != nil {
+			return err
+		}
+
+		// 2. 校验已购买和待支付订单
+		var conflict []int64
+		qc := tx.UserCourseGood
+		err := qc.WithContext(ctx).Where(qc.UserID.Eq(req.UserID), qc.GoodsID.In(goodsIDs...)).Limit(1).Pluck(qc.GoodsID, &conflict)
+		if err != nil {
+			return err
+		}
+		if len(conflict) > 0 {
+			return &GoodsConflictError{GoodsID: conflict[0]}
+		}
+		var ordered []struct {
+			GoodsID int64
+			Status  int32
+		}
+		qi, qo := tx.OrderItem, tx.Order
+		err = qi.WithContext(ctx).Select(qi.GoodsID, qo.Status).Join(qo, qo.ID.EqCol(qi.OrderID)).
+			Where(qi.UserID.Eq(req.UserID), qi.GoodsID.In(goodsIDs...), qo.Status.In(ownedStatuses...)).
+			Limit(1).Scan(&ordered)
+		if err != nil {
+			return err
+		}
+		if len(ordered) > 0 {
+			return &GoodsConflictError{GoodsID: ordered[0].GoodsID, Pending: ordered[0].Status == consts.OrderStatusPending}
+		}
+
+		// 3. 写入订单和订单商品
+		if err = tx.Order.WithContext(ctx).Create(addObj); err != nil {
+			return err
+		}
+		items := make([]*model.OrderItem, 0, len(req.Items))
+		for _, item := range req.Items {
+			items = append(items, &model.OrderItem{
+				OrderID:           addObj.ID,
+				UserID:            req.UserID,
+				GoodsID:           item.GoodsID,
+				GoodsType:         item.GoodsType,
+				Quantity:          1, // 课程商品每单只能购买一份
+				GoodsSnap:         item.GoodsSnap,
+				ServiceExpireTime: item.ServiceExpireTime,
+			})
+		}
+		if err = tx.OrderItem.WithContext(ctx).Create(items...); err != nil || !req.AutoPay {
+			return err
+		}
+
+		// 4. 0元订单直接完成支付并发放课程
+		err = tx.OrderStatusLog.WithContext(ctx).Create(&model.OrderStatusLog{
+			OrderID:      addObj.ID,
+			FromStatus:   consts.OrderStatusPending,
+			ToStatus:     consts.OrderStatusPaid,
+			OperatorType: consts.OrderOperatorSystem,
+			OperatorID:   consts.SystemOperatorID,
+			Remark:       "0元订单自动完成支付",
+			CreateAt:     timeNow,
+		})
+		if err != nil {
+			return err
+		}
+		return tx.UserCourseGood.WithContext(ctx).Create(OwnedGoods(items, timeNow)...)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return addObj.ID, nil
+}
+
+// GetOrder 根据ID获取订单
+// 参数:
+//   - ctx: 上下文
+//   - id: 订单ID
+//
+// 返回: 订单对象和错误信息,不存在返回gorm.ErrRecordNotFound
+// 调用链: orderstate.Transit -> repo.GetOrder -> GORM.First
+func (o *Order) GetOrder(ctx context.Context, id int64) (*model.Order, error) {
+	qs := query.Use(o.db).Order
+	return qs.WithContext(ctx).Where(qs.ID.Eq(id)).First()
+}
+
+// ListTimeoutOrders 获取指定时间前创建且仍待支付的订单
+// 参数:
+//   - ctx: 上下文
+//   - createBefore: 创建时间上限(不含),毫秒时间戳
+//   - limit: 最多返回条数
+//
+// 返回: 订单列表(只包含ID和CreateAt,按创建时间升序)和错误信息
+// 索引: idx_status_create_at(status, create_at)
+// 调用链: service/user.SweepTimeoutOrders -> repo.ListTimeoutOrders -> GORM.Find
+func (o *Order) ListTimeoutOrders(ctx context.Context, createBefore int64, limit int) ([]*model.Order, error) {
+	qs := query.Use(o.db).Order
+	return qs.WithContext(ctx).Select(qs.ID, qs.CreateAt).
+		Where(qs.Status.Eq(consts.OrderStatusPending), qs.CreateAt.Lt(createBefore)).
+		Order(qs.CreateAt).Limit(limit).Find()
+}
+
+// TransitOrder 变更订单状态
+// 参数:
+//   - ctx: 上下文
+//   - req: 状态变更请求DO对象
+//
+// 返回: 是否变更成功和错误信息
+// 乐观更新: 条件为WHERE id = ? AND status = FromStatus,订单状态已被并发修改时不更新,返回false
+// 时间字段: 按目标状态写入支付、取消、退款或确认收货的时间及相关字段
+// 发放课程: 流转到已支付时按订单商品写入已购课程,购买时间为支付时间
+// 特性: 订单更新、流转记录和已购课程在同一事务中写入,没有更新时都不写入
+// 调用链: orderstate.Transit -> repo.TransitOrder -> GORM.Transaction
+func (o *Order) TransitOrder(ctx context.Context, req *do.TransitOrder) (bool, error) {
+	transited := false
+	err := query.Use(o.db).Transaction(func(tx *query.Query) error {
+		qs := tx.Order
+		columns := []field.AssignExpr{qs.Status.Value(req.ToStatus)}
+		switch req.ToStatus {
+		case consts.OrderStatusPaid:
+			columns = append(columns,
+				qs.PaymentAt.Value(req.TransitAt),
+				qs.PaymentAmount.Value(req.PaymentAmount),
+				qs.TradeNo.Value(req.TradeNo),
+			)
+		case consts.OrderStatusCancelled:
+			columns = append(columns,
+				qs.CancelAt.Value(req.TransitAt),
+				qs.CancelType.Value(req.CancelType),
+				qs.CancelBy.Value(req.OperatorID),
+				qs.CancelReason.Value(req.Remark),
+			)
+		case consts.OrderStatusRefunded:
+			columns = append(columns,
+				qs.RefundAt.Value(req.TransitAt),
+				qs.RefundAmount.Value(req.RefundAmount),
+			)
+		case consts.OrderStatusReceived:
+			columns = append(columns,
+				qs.ReceiverConfirmAt.Value(req.TransitAt),
+				qs.ReceiverConfirmType.Value(req.ConfirmType),
+			)
+		}
+		info, err := qs.WithContext(ctx).Where(qs.ID.Eq(req.OrderID), qs.Status.Eq(req.FromStatus)).UpdateSimple(columns...)
+		if err != nil || info.RowsAffected == 0 {
+			return err
+		}
+		err = tx.OrderStatusLog.WithContext(ctx).Create(&model.OrderStatusLog{
+			OrderID:      req.OrderID,
+			FromStatus:   req.FromStatus,
+			ToStatus:     req.ToStatus,
+			OperatorType: req.OperatorType,
+			OperatorID:   req.OperatorID,
+			Remark:       req.Remark,
+			CreateAt:     req.TransitAt,
+		})
+		if err != nil {
+			return err
+		}
+		if req.ToStatus == consts.OrderStatusPaid {
+			qi := tx.OrderItem
+			items, err := qi.WithContext(ctx).Where(qi.OrderID.Eq(req.OrderID)).Find()
+			if err != nil {
+				return err
+			}
+			if err = tx.UserCourseGood.WithContext(ctx).Create(OwnedGoods(items, req.TransitAt)...); err != nil {
+				return err
+			}
+		}
+		transited = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return transited, nil
+}
+
+// OwnedGoods 根据已支付订单的订单商品生成已购课程
+// 参数:
+//   - items: 订单商品
+//   - buyTime: 购买时间(支付时间),毫秒时间戳
+//
+// 返回: 已购课程列表,辅导到期时间取自订单商品
+// 调用链: repo.CreateOrder / repo.TransitOrder -> OwnedGoods
+func OwnedGoods(items []*model.OrderItem, buyTime int64) []*model.UserCourseGood {
+	owned := make([]*model.UserCourseGood, 0, len(items))
+	for _, item := range items {
+		owned = append(owned, &model.UserCourseGood{
+			UserID:            item.UserID,
+			OrderID:           item.OrderID,
+			GoodsID:           item.GoodsID,
+			GoodsType:         item.GoodsType,
+			BuyTime:           buyTime,
+			ServiceExpireTime: item.ServiceExpireTime,
+		})
+	}
+	return owned
+}
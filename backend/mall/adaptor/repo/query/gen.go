@@ -17,71 +17,80 @@ import (
 
 func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 	return &Query{
-		db:                 db,
-		AdminUser:          newAdminUser(db, opts...),
-		AdminUserRole:      newAdminUserRole(db, opts...),
-		AppUser:            newAppUser(db, opts...),
-		CourseCatalog:      newCourseCatalog(db, opts...),
-		CourseGood:         newCourseGood(db, opts...),
-		CourseLesson:       newCourseLesson(db, opts...),
-		MobileUser:         newMobileUser(db, opts...),
-		Order:              newOrder(db, opts...),
-		OrderItem:          newOrderItem(db, opts...),
-		Permission:         newPermission(db, opts...),
-		ResourceUploadFile: newResourceUploadFile(db, opts...),
-		Role:               newRole(db, opts...),
-		RolePermission:     newRolePermission(db, opts...),
-		SmsTemplate:        newSmsTemplate(db, opts...),
-		User:               newUser(db, opts...),
-		UserCourseGood:     newUserCourseGood(db, opts...),
-		WechatUser:         newWechatUser(db, opts...),
+		db:                   db,
+		AdminUser:            newAdminUser(db, opts...),
+		AdminUserRole:        newAdminUserRole(db, opts...),
+		AppUser:              newAppUser(db, opts...),
+		CourseCatalog:        newCourseCatalog(db, opts...),
+		CourseGood:           newCourseGood(db, opts...),
+		CourseGoodsPrice:     newCourseGoodsPrice(db, opts...),
+		CourseGoodsStatusLog: newCourseGoodsStatusLog(db, opts...),
+		CourseLesson:         newCourseLesson(db, opts...),
+		MobileUser:           newMobileUser(db, opts...),
+		Order:                newOrder(db, opts...),
+		OrderItem:            newOrderItem(db, opts...),
+		OrderStatusLog:       newOrderStatusLog(db, opts...),
+		Permission:           newPermission(db, opts...),
+		ResourceUploadFile:   newResourceUploadFile(db, opts...),
+		Role:                 newRole(db, opts...),
+		RolePermission:       newRolePermission(db, opts...),
+		SmsTemplate:          newSmsTemplate(db, opts...),
+		User:                 newUser(db, opts...),
+		UserCourseGood:       newUserCourseGood(db, opts...),
+		WechatUser:           newWechatUser(db, opts...),
 	}
 }
 
 type Query struct {
 	db *gorm.DB
 
-	AdminUser          adminUser
-	AdminUserRole      adminUserRole
-	AppUser            appUser
-	CourseCatalog      courseCatalog
-	CourseGood         courseGood
-	CourseLesson       courseLesson
-	MobileUser         mobileUser
-	Order              order
-	OrderItem          orderItem
-	Permission         permission
-	ResourceUploadFile resourceUploadFile
-	Role               role
-	RolePermission     rolePermission
-	SmsTemplate        smsTemplate
-	User               user
-	UserCourseGood     userCourseGood
-	WechatUser         wechatUser
+	AdminUser            adminUser
+	AdminUserRole        adminUserRole
+	AppUser              appUser
+	CourseCatalog        courseCatalog
+	CourseGood           courseGood
+	CourseGoodsPrice     courseGoodsPrice
+	CourseGoodsStatusLog courseGoodsStatusLog
+	CourseLesson         courseLesson
+	MobileUser           mobileUser
+	Order                order
+	OrderItem            orderItem
+	OrderStatusLog       orderStatusLog
+	Permission           permission
+	ResourceUploadFile   resourceUploadFile
+	Role                 role
+	RolePermission       rolePermission
+	SmsTemplate          smsTemplate
+	User                 user
+	UserCourseGood       userCourseGood
+	WechatUser           wechatUser
 }
 
 func (q *Query) Available() bool { return q.db != nil }
 
 func (q *Query) clone(db *gorm.DB) *Query {
 	return &Query{
-		db:                 db,
-		AdminUser:          q.AdminUser.clone(db),
-		AdminUserRole:      q.AdminUserRole.clone(db),
-		AppUser:            q.AppUser.clone(db),
-		CourseCatalog:      q.CourseCatalog.clone(db),
-		CourseGood:         q.CourseGood.clone(db),
-		CourseLesson:       q.CourseLesson.clone(db),
-		MobileUser:         q.MobileUser.clone(db),
-		Order:              q.Order.clone(db),
-		OrderItem:          q.OrderItem.clone(db),
-		Permission:         q.Permission.clone(db),
-		ResourceUploadFile: q.ResourceUploadFile.clone(db),
-		Role:               q.Role.clone(db),
-		RolePermission:     q.RolePermission.clone(db),
-		SmsTemplate:        q.SmsTemplate.clone(db),
-		User:               q.User.clone(db),
-		UserCourseGood:     q.UserCourseGood.clone(db),
-		WechatUser:         q.WechatUser.clone(db),
+		db:                   db,
+		AdminUser:            q.AdminUser.clone(db),
+		AdminUserRole:        q.AdminUserRole.clone(db),
+		AppUser:              q.AppUser.clone(db),
+		CourseCatalog:        q.CourseCatalog.clone(db),
+		CourseGood:           q.CourseGood.clone(db),
+		CourseGoodsPrice:     q.CourseGoodsPrice.clone(db),
+		CourseGoodsStatusLog: q.CourseGoodsStatusLog.clone(db),
+		CourseLesson:         q.CourseLesson.clone(db),
+		MobileUser:           q.MobileUser.clone(db),
+		Order:                q.Order.clone(db),
+		OrderItem:            q.OrderItem.clone(db),
+		OrderStatusLog:       q.OrderStatusLog.clone(db),
+		Permission:           q.Permission.clone(db),
+		ResourceUploadFile:   q.ResourceUploadFile.clone(db),
+		Role:                 q.Role.clone(db),
+		RolePermission:       q.RolePermission.clone(db),
+		SmsTemplate:          q.SmsTemplate.clone(db),
+		User:                 q.User.clone(db),
+		UserCourseGood:       q.UserCourseGood.clone(db),
+		WechatUser:           q.WechatUser.clone(db),
 	}
 }
 
@@ -95,66 +104,75 @@ func (q *Query) WriteDB() *Query {
 
 func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 	return &Query{
-		db:                 db,
-		AdminUser:          q.AdminUser.replaceDB(db),
-		AdminUserRole:      q.AdminUserRole.replaceDB(db),
-		AppUser:            q.AppUser.replaceDB(db),
-		CourseCatalog:      q.CourseCatalog.replaceDB(db),
-		CourseGood:         q.CourseGood.replaceDB(db),
-		CourseLesson:       q.CourseLesson.replaceDB(db),
-		MobileUser:         q.MobileUser.replaceDB(db),
-		Order:              q.Order.replaceDB(db),
-		OrderItem:          q.OrderItem.replaceDB(db),
-		Permission:         q.Permission.replaceDB(db),
-		ResourceUploadFile: q.ResourceUploadFile.replaceDB(db),
-		Role:               q.Role.replaceDB(db),
-		RolePermission:     q.RolePermission.replaceDB(db),
-		SmsTemplate:        q.SmsTemplate.replaceDB(db),
-		User:               q.User.replaceDB(db),
-		UserCourseGood:     q.UserCourseGood.replaceDB(db),
-		WechatUser:         q.WechatUser.replaceDB(db),
+		db:                   db,
+		AdminUser:            q.AdminUser.replaceDB(db),
+		AdminUserRole:        q.AdminUserRole.replaceDB(db),
+		AppUser:              q.AppUser.replaceDB(db),
+		CourseCatalog:        q.CourseCatalog.replaceDB(db),
+		CourseGood:           q.CourseGood.replaceDB(db),
+		CourseGoodsPrice:     q.CourseGoodsPrice.replaceDB(db),
+		CourseGoodsStatusLog: q.CourseGoodsStatusLog.replaceDB(db),
+		CourseLesson:         q.CourseLesson.replaceDB(db),
+		MobileUser:           q.MobileUser.replaceDB(db),
+		Order:                q.Order.replaceDB(db),
+		OrderItem:            q.OrderItem.replaceDB(db),
+		OrderStatusLog:       q.OrderStatusLog.replaceDB(db),
+		Permission:           q.Permission.replaceDB(db),
+		ResourceUploadFile:   q.ResourceUploadFile.replaceDB(db),
+		Role:                 q.Role.replaceDB(db),
+		RolePermission:       q.RolePermission.replaceDB(db),
+		SmsTemplate:          q.SmsTemplate.replaceDB(db),
+		User:                 q.User.replaceDB(db),
+		UserCourseGood:       q.UserCourseGood.replaceDB(db),
+		WechatUser:           q.WechatUser.replaceDB(db),
 	}
 }
 
 type queryCtx struct {
-	AdminUser          *adminUserDo
-	AdminUserRole      *adminUserRoleDo
-	AppUser            *appUserDo
-	CourseCatalog      *courseCatalogDo
-	CourseGood         *courseGoodDo
-	CourseLesson       *courseLessonDo
-	MobileUser         *mobileUserDo
-	Order              *orderDo
-	OrderItem          *orderItemDo
-	Permission         *permissionDo
-	ResourceUploadFile *resourceUploadFileDo
-	Role               *roleDo
-	RolePermission     *rolePermissionDo
-	SmsTemplate        *smsTemplateDo
-	User               *userDo
-	UserCourseGood     *userCourseGoodDo
-	WechatUser         *wechatUserDo
+	AdminUser            *adminUserDo
+	AdminUserRole        *adminUserRoleDo
+	AppUser              *appUserDo
+	CourseCatalog        *courseCatalogDo
+	CourseGood           *courseGoodDo
+	CourseGoodsPrice     *courseGoodsPriceDo
+	CourseGoodsStatusLog *courseGoodsStatusLogDo
+	CourseLesson         *courseLessonDo
+	MobileUser           *mobileUserDo
+	Order                *orderDo
+	OrderItem            *orderItemDo
+	OrderStatusLog       *orderStatusLogDo
+	Permission           *permissionDo
+	ResourceUploadFile   *resourceUploadFileDo
+	Role                 *roleDo
+	RolePermission       *rolePermissionDo
+	SmsTemplate          *smsTemplateDo
+	User                 *userDo
+	UserCourseGood       *userCourseGoodDo
+	WechatUser           *wechatUserDo
 }
 
 func (q *Query) WithContext(ctx context.Context) *queryCtx {
 	return &queryCtx{
-		AdminUser:          q.AdminUser.WithContext(ctx),
-		AdminUserRole:      q.AdminUserRole.WithContext(ctx),
-		AppUser:            q.AppUser.WithContext(ctx),
-		CourseCatalog:      q.CourseCatalog.WithContext(ctx),
-		CourseGood:         q.CourseGood.WithContext(ctx),
-		CourseLesson:       q.CourseLesson.WithContext(ctx),
-		MobileUser:         q.MobileUser.WithContext(ctx),
-		Order:              q.Order.WithContext(ctx),
-		OrderItem:          q.OrderItem.WithContext(ctx),
-		Permission:         q.Permission.WithContext(ctx),
-		ResourceUploadFile: q.ResourceUploadFile.WithContext(ctx),
-		Role:               q.Role.WithContext(ctx),
-		RolePermission:     q.RolePermission.WithContext(ctx),
-		SmsTemplate:        q.SmsTemplate.WithContext(ctx),
-		User:               q.User.WithContext(ctx),
-		UserCourseGood:     q.UserCourseGood.WithContext(ctx),
-		WechatUser:         q.WechatUser.WithContext(ctx),
+		AdminUser:            q.AdminUser.WithContext(ctx),
+		AdminUserRole:        q.AdminUserRole.WithContext(ctx),
+		AppUser:              q.AppUser.WithContext(ctx),
+		CourseCatalog:        q.CourseCatalog.WithContext(ctx),
+		CourseGood:           q.CourseGood.WithContext(ctx),
+		CourseGoodsPrice:     q.CourseGoodsPrice.WithContext(ctx),
+		CourseGoodsStatusLog: q.CourseGoodsStatusLog.WithContext(ctx),
+		CourseLesson:         q.CourseLesson.WithContext(ctx),
+		MobileUser:           q.MobileUser.WithContext(ctx),
+		Order:                q.Order.WithContext(ctx),
+		OrderItem:            q.OrderItem.WithContext(ctx),
+		OrderStatusLog:       q.OrderStatusLog.WithContext(ctx),
+		Permission:           q.Permission.WithContext(ctx),
+		ResourceUploadFile:   q.ResourceUploadFile.WithContext(ctx),
+		Role:                 q.Role.WithContext(ctx),
+		RolePermission:       q.RolePermission.WithContext(ctx),
+		SmsTemplate:          q.SmsTemplate.WithContext(ctx),
+		User:                 q.User.WithContext(ctx),
+		UserCourseGood:       q.UserCourseGood.WithContext(ctx),
+		WechatUser:           q.WechatUser.WithContext(ctx),
 	}
 }
 
@@ -34,6 +34,7 @@ func newOrderItem(db *gorm.DB, opts ...gen.DOOption) orderItem {
 	_orderItem.GoodsType = field.NewInt32(tableName, "goods_type")
 	_orderItem.Quantity = field.NewInt32(tableName, "quantity")
 	_orderItem.GoodsSnap = field.NewString(tableName, "goods_snap")
+	_orderItem.ServiceExpireTime = field.NewInt64(tableName, "service_expire_time")
 
 	_orderItem.fillFieldMap()
 
@@ -44,14 +45,15 @@ func newOrderItem(db *gorm.DB, opts ...gen.DOOption) orderItem {
 type orderItem struct {
 	orderItemDo orderItemDo
 
-	ALL       field.Asterisk
-	ID        field.Int64
-	OrderID   field.Int64
-	UserID    field.Int64
-	GoodsID   field.Int64  // 商品ID
-	GoodsType field.Int32  // 1:课程商品
-	Quantity  field.Int32  // 商品数量
-	GoodsSnap field.String // 商品快照信息
+	ALL               field.Asterisk
+	ID                field.Int64
+	OrderID           field.Int64
+	UserID            field.Int64
+	GoodsID           field.Int64  // 商品ID
+	GoodsType         field.Int32  // 1:课程商品
+	Quantity          field.Int32  // 商品数量
+	GoodsSnap         field.String // 商品快照信息
+	ServiceExpireTime field.Int64  // 辅导到期时间
 
 	fieldMap map[string]field.Expr
 }
@@ -75,6 +77,7 @@ func (o *orderItem) updateTableName(table string) *orderItem {
 	o.GoodsType = field.NewInt32(table, "goods_type")
 	o.Quantity = field.NewInt32(table, "quantity")
 	o.GoodsSnap = field.NewString(table, "goods_snap")
+	o.ServiceExpireTime = field.NewInt64(table, "service_expire_time")
 
 	o.fillFieldMap()
 
@@ -101,7 +104,7 @@ func (o *orderItem) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (o *orderItem) fillFieldMap() {
-	o.fieldMap = make(map[string]field.Expr, 7)
+	o.fieldMap = make(map[string]field.Expr, 8)
 	o.fieldMap["id"] = o.ID
 	o.fieldMap["order_id"] = o.OrderID
 	o.fieldMap["user_id"] = o.UserID
@@ -109,6 +112,7 @@ func (o *orderItem) fillFieldMap() {
 	o.fieldMap["goods_type"] = o.GoodsType
 	o.fieldMap["quantity"] = o.Quantity
 	o.fieldMap["goods_snap"] = o.GoodsSnap
+	o.fieldMap["service_expire_time"] = o.ServiceExpireTime
 }
 
 func (o orderItem) clone(db *gorm.DB) orderItem {
@@ -0,0 +1,6 @@
+-- 管理员表: 未删除的管理员手机号唯一,已删除的管理员不占用手机号
+-- 应用层先校验再写入,并发创建或恢复时由唯一索引兜底,冲突时返回MobileExistErr
+-- active_mobile只在未删除且手机号非空时有值,其余为NULL,不参与唯一约束
+ALTER TABLE `admin_user`
+  ADD COLUMN `active_mobile` varchar(32) GENERATED ALWAYS AS (IF(`is_delete` = 0 AND `mobile` <> '', `mobile`, NULL)) VIRTUAL COMMENT '未删除管理员的手机号，用于唯一约束',
+  ADD UNIQUE KEY `uk_active_mobile` (`active_mobile`);
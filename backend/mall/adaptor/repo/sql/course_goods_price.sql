@@ -0,0 +1,14 @@
+-- 课程商品价格表
+-- 每次调价新增一条记录,生效区间内EffectiveFrom最晚的记录为当前价格
+-- 建表后执行 make gendb 重新生成 model/query
+CREATE TABLE `course_goods_price` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `goods_id` bigint NOT NULL COMMENT '课程商品ID',
+  `price` bigint NOT NULL COMMENT '价格，单位分',
+  `effective_from` datetime(3) NOT NULL COMMENT '生效时间',
+  `effective_to` datetime(3) DEFAULT NULL COMMENT '失效时间，NULL表示长期有效',
+  `create_at` datetime(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `create_by` bigint NOT NULL COMMENT '创建人ID',
+  PRIMARY KEY (`id`),
+  KEY `idx_goods_effective` (`goods_id`, `effective_from`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='课程商品价格表';
@@ -0,0 +1,13 @@
+-- 课程商品上下架记录表
+-- 商品每上架或下架一次新增一条记录,与状态更新在同一事务中写入
+-- 建表后执行 make gendb 重新生成 model/query
+CREATE TABLE `course_goods_status_log` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `goods_id` bigint NOT NULL COMMENT '课程商品ID',
+  `from_status` int NOT NULL COMMENT '变更前状态',
+  `to_status` int NOT NULL COMMENT '-1：下架 1：上架',
+  `create_at` datetime(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '操作时间',
+  `create_by` bigint NOT NULL COMMENT '操作人ID',
+  PRIMARY KEY (`id`),
+  KEY `idx_goods_id` (`goods_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='课程商品上下架记录表';
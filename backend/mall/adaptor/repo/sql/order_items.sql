@@ -0,0 +1,4 @@
+-- 订单商品表: 下单时保存辅导到期时间,订单支付后按订单商品写入已购课程
+-- 执行后执行 make gendb 重新生成 model/query
+ALTER TABLE `order_items`
+  ADD COLUMN `service_expire_time` bigint NOT NULL DEFAULT 0 COMMENT '辅导到期时间' AFTER `goods_snap`;
@@ -0,0 +1,15 @@
+-- 订单状态流转记录表
+-- 订单状态每变更一次新增一条记录,由订单状态机在同一事务中写入
+-- 建表后执行 make gendb 重新生成 model/query
+CREATE TABLE `order_status_log` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `order_id` bigint NOT NULL COMMENT '订单ID',
+  `from_status` int NOT NULL COMMENT '变更前状态',
+  `to_status` int NOT NULL COMMENT '变更后状态',
+  `operator_type` int NOT NULL COMMENT '1：用户  2：管理员  3：系统',
+  `operator_id` bigint NOT NULL COMMENT '操作人ID，系统为-1',
+  `remark` varchar(255) NOT NULL DEFAULT '' COMMENT '备注',
+  `create_at` bigint NOT NULL COMMENT '变更时间，毫秒时间戳',
+  PRIMARY KEY (`id`),
+  KEY `idx_order_id` (`order_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='订单状态流转记录表';
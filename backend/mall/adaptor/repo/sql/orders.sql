@@ -0,0 +1,9 @@
+-- 订单表: 超时未支付订单的数据库兜底扫描按状态和创建时间查询
+-- 条件为 status = 1 AND create_at < ?,按create_at升序分批读取
+ALTER TABLE `orders`
+  ADD KEY `idx_status_create_at` (`status`, `create_at`);
+
+-- 订单表: 订单号唯一
+-- 订单号由下单时生成,唯一索引保证不会出现重复的订单号
+ALTER TABLE `orders`
+  ADD UNIQUE KEY `uk_order_no` (`order_no`);
@@ -0,0 +1,4 @@
+-- 权限表: 权限编码唯一
+-- 应用层先校验再写入,并发写入时由唯一索引兜底,冲突时返回PermCodeExistErr
+ALTER TABLE `permission`
+  ADD UNIQUE KEY `uk_code` (`code`);
@@ -0,0 +1,4 @@
+-- 角色表: 角色名称唯一
+-- 应用层先校验再写入,并发写入时由唯一索引兜底,冲突时返回RoleNameExistErr
+ALTER TABLE `roles`
+  ADD UNIQUE KEY `uk_name` (`name`);
@@ -0,0 +1,6 @@
+-- 短信模板表: 同一场景只能有一个启用中的模板
+-- 应用层先校验再写入,并发写入时由唯一索引兜底,冲突时返回SmsSceneExistErr
+-- enabled_scene_code只在启用状态下有值,禁用的模板为NULL,不参与唯一约束
+ALTER TABLE `sms_template`
+  ADD COLUMN `enabled_scene_code` varchar(64) GENERATED ALWAYS AS (IF(`status` = 1, `scene_code`, NULL)) VIRTUAL COMMENT '启用中模板的场景编码，用于唯一约束',
+  ADD UNIQUE KEY `uk_enabled_scene_code` (`enabled_scene_code`);
@@ -0,0 +1,84 @@
+// Package user 前台用户数据访问层
+// 职责: 封装user表及mobile_user表的读写操作
+// 调用链: service -> repo -> GORM
+package user
+
+import (
+	"context"
+	"mall/adaptor"
+	"mall/adaptor/repo/model"
+	"mall/adaptor/repo/query"
+	"mall/service/do"
+	"mall/utils/tools"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// IUser 前台用户数据访问接口
+type IUser interface {
+	GetUserInfo(ctx context.Context, userId int64) (*model.User, error)           // 获取用户详细信息
+	GetUserByMobile(ctx context.Context, mobile string) (*model.User, error)      // 根据手机号获取用户
+	UpdateUserPassword(ctx context.Context, req *do.UpdateCustomerPassword) error // 更新用户密码
+}
+
+// User 前台用户数据访问实现
+type User struct {
+	db *gorm.DB // 数据库连接
+}
+
+// NewUser 创建前台用户数据访问实例
+// 参数: adaptor 适配器,提供数据库连接
+// 返回: User实例
+// 调用链: service/user.NewService -> NewUser
+func NewUser(adaptor adaptor.IAdaptor) *User {
+	return &User{
+		db: adaptor.GetDB(),
+	}
+}
+
+// GetUserInfo 获取用户详细信息
+// 参数:
+//   - ctx: 上下文
+//   - userId: 用户ID
+//
+// 返回: 用户对象和错误信息
+// 调用链: service/user.ParseToken -> repo.GetUserInfo -> GORM.First
+func (u *User) GetUserInfo(ctx context.Context, userId int64) (*model.User, error) {
+	qs := query.Use(u.db).User
+	return qs.WithContext(ctx).Where(qs.ID.Eq(userId)).First()
+}
+
+// GetUserByMobile 根据手机号获取用户
+// 参数:
+//   - ctx: 上下文
+//   - mobile: 手机号明文
+//
+// 返回: 用户对象和错误信息,不存在返回gorm.ErrRecordNotFound
+// 查询方式: 手机号SHA256后匹配mobile_user.mobile_sha256,再按user_id查询user表
+// 调用链: service/user.MobilePasswordLogin -> repo.GetUserByMobile -> GORM.First
+func (u *User) GetUserByMobile(ctx context.Context, mobile string) (*model.User, error) {
+	mq := query.Use(u.db).MobileUser
+	mobileUser, err := mq.WithContext(ctx).Where(mq.MobileSha256.Eq(tools.Sha256Hash(mobile))).First()
+	if err != nil {
+		return nil, err
+	}
+	return u.GetUserInfo(ctx, mobileUser.UserID)
+}
+
+// UpdateUserPassword 更新用户密码
+// 参数:
+//   - ctx: 上下文
+//   - req: 更新密码请求DO对象
+//
+// 返回: 错误信息
+// 注意: 传入的password应该已经是加盐哈希后的值
+// 调用链: service/user.upgradePassword -> repo.UpdateUserPassword -> GORM.Updates
+func (u *User) UpdateUserPassword(ctx context.Context, req *do.UpdateCustomerPassword) error {
+	qs := query.Use(u.db).User
+	_, err := qs.WithContext(ctx).Where(qs.ID.Eq(req.ID)).Updates(model.User{
+		Password: req.Password, // 哈希后的密码
+		UpdateAt: time.Now(),
+	})
+	return err
+}
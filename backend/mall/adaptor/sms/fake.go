@@ -0,0 +1,76 @@
+// Package sms 短信平台适配层-本地假通道
+// 职责: 不调用任何短信平台,只记录短信内容
+// 用途: 本地开发和CI环境无需真实短信账号即可走通验证码登录等流程
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"go.uber.org/zap"
+	"mall/utils/logger"
+	"os"
+	"sync"
+	"time"
+)
+
+// fakeRecord 假通道发送记录,每条短信一行JSON
+type fakeRecord struct {
+	SendAt     string   `json:"send_at"`     // 发送时间
+	Platform   string   `json:"platform"`    // 模板配置的平台
+	Mobile     string   `json:"mobile"`      // 接收手机号
+	SignName   string   `json:"sign_name"`   // 短信签名
+	TemplateID string   `json:"template_id"` // 平台模板ID
+	Params     []string `json:"params"`      // 模板参数
+	Content    string   `json:"content"`     // 渲染后的短信内容
+}
+
+// Fake 本地假通道实现
+type Fake struct {
+	file string     // 记录文件路径,为空时只输出日志
+	mu   sync.Mutex // 保证并发写文件时每条记录完整
+}
+
+// NewFake 创建本地假通道
+// 参数: file 记录文件路径(JSON Lines),为空时只输出日志
+// 返回: Fake实例
+// 调用链: NewSender -> NewFake
+func NewFake(file string) *Fake {
+	return &Fake{file: file}
+}
+
+// Send 记录短信
+// 参数:
+//   - ctx: 上下文
+//   - msg: 待发送的短信
+//
+// 返回: 写入记录文件失败时返回错误
+// 输出: 日志(含完整内容) + 记录文件追加一行JSON
+func (f *Fake) Send(ctx context.Context, msg *Message) error {
+	record := fakeRecord{
+		SendAt:     time.Now().Format("2006-01-02 15:04:05.000"),
+		Platform:   msg.Platform,
+		Mobile:     msg.Mobile,
+		SignName:   msg.SignName,
+		TemplateID: msg.TemplateID,
+		Params:     msg.Params,
+		Content:    msg.Content,
+	}
+	logger.Info("fake sms", zap.Any("record", record))
+	if f.file == "" {
+		return nil
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	fd, err := os.OpenFile(f.file, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+	_, err = fd.Write(append(line, '\n'))
+	return err
+}
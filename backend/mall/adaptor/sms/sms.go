@@ -0,0 +1,164 @@
+// Package sms 短信平台适配层
+// 职责: 屏蔽不同短信平台的差异,按短信模板配置的平台发送短信
+// 支持平台: 腾讯云(tencent)、容联云(ronglian)、本地假通道(fake)
+// 特性: 每次发送均输出发送记录日志,假通道额外写入记录文件,便于本地和CI环境读取验证码
+package sms
+
+import (
+	"context"
+	"fmt"
+	"go.uber.org/zap"
+	"mall/adaptor/repo/model"
+	"mall/config"
+	"mall/utils/logger"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 短信平台编码,与sms_template.platform对应
+const (
+	PlatformTencent  = "tencent"  // 腾讯云
+	PlatformRonglian = "ronglian" // 容联云
+	PlatformFake     = "fake"     // 本地假通道,不真实发送
+)
+
+// httpTimeout 调用短信平台接口的超时时间
+const httpTimeout = time.Second * 5
+
+// placeholderReg 模板参数占位符,格式{1}、{2}...,与腾讯云、容联云模板格式一致
+var placeholderReg = regexp.MustCompile(`\{(\d+)\}`)
+
+// Message 待发送的短信
+type Message struct {
+	Platform   string   // 模板配置的平台
+	Mobile     string   // 接收手机号
+	SignName   string   // 短信签名
+	TemplateID string   // 平台模板ID
+	Params     []string // 模板参数,按占位符序号排列
+	Content    string   // 渲染后的短信内容,仅用于记录
+}
+
+// ISender 短信平台发送接口
+type ISender interface {
+	Send(ctx context.Context, msg *Message) error // 发送短信
+}
+
+// Sender 短信发送器
+// 按模板的Platform分发到对应平台,Fake配置开启时统一走假通道
+type Sender struct {
+	forceFake bool               // 是否强制使用假通道
+	fake      ISender            // 假通道
+	providers map[string]ISender // 平台编码 -> 平台实现
+}
+
+// NewSender 创建短信发送器
+// 参数: conf 短信平台配置
+// 返回: Sender实例
+// 调用链: service.NewService -> NewSender
+func NewSender(conf config.Sms) *Sender {
+	fake := NewFake(conf.FakeFile)
+	return &Sender{
+		forceFake: conf.Fake,
+		fake:      fake,
+		providers: map[string]ISender{
+			PlatformTencent:  NewTencent(conf.Tencent),
+			PlatformRonglian: NewRonglian(conf.Ronglian),
+			PlatformFake:     fake,
+		},
+	}
+}
+
+// Send 按短信模板发送短信
+// 参数:
+//   - ctx: 上下文
+//   - tmpl: 短信模板(决定平台、签名、模板ID和内容)
+//   - mobile: 接收手机号
+//   - params: 模板参数,按占位符序号排列,多余的参数会被忽略
+//
+// 返回: 错误信息
+// 业务流程:
+//  1. 按模板占位符数量裁剪参数(平台要求参数个数与模板一致)
+//  2. 渲染短信内容
+//  3. 选择平台发送
+//  4. 输出发送记录
+func (s *Sender) Send(ctx context.Context, tmpl *model.SmsTemplate, mobile string, params []string) error {
+	// 1. 裁剪参数
+	if n := ParamCount(tmpl.TmplStr); len(params) > n {
+		params = params[:n]
+	}
+
+	msg := &Message{
+		Platform:   tmpl.Platform,
+		Mobile:     mobile,
+		SignName:   tmpl.SignName,
+		TemplateID: strconv.Itoa(int(tmpl.PlatformTmplID)),
+		Params:     params,
+		Content:    Render(tmpl.TmplStr, params), // 2. 渲染内容
+	}
+
+	// 3. 选择平台发送
+	begin := time.Now()
+	sender, err := s.provider(tmpl.Platform)
+	if err == nil {
+		err = sender.Send(ctx, msg)
+	}
+
+	// 4. 输出发送记录
+	fields := []zap.Field{
+		zap.String("scene", tmpl.SceneCode),
+		zap.Int64("template_id", tmpl.ID),
+		zap.String("platform", tmpl.Platform),
+		zap.Bool("fake", s.forceFake),
+		zap.String("mobile", mobile),
+		zap.Int64("dur_ms", time.Since(begin).Milliseconds()),
+	}
+	if err != nil {
+		logger.Error("sms_send_record", append(fields, zap.Error(err))...)
+		return err
+	}
+	logger.Info("sms_send_record", fields...)
+	return nil
+}
+
+// provider 根据平台编码选择平台实现
+func (s *Sender) provider(platform string) (ISender, error) {
+	if s.forceFake {
+		return s.fake, nil
+	}
+	sender, ok := s.providers[platform]
+	if !ok {
+		return nil, fmt.Errorf("sms: unsupported platform %q", platform)
+	}
+	return sender, nil
+}
+
+// Render 渲染短信模板
+// 参数:
+//   - tmplStr: 模板内容,如"您的验证码为{1}，{2}分钟内有效"
+//   - params: 模板参数,params[0]替换{1},以此类推
+//
+// 返回: 渲染后的内容,缺少参数的占位符保持原样
+func Render(tmplStr string, params []string) string {
+	return placeholderReg.ReplaceAllStringFunc(tmplStr, func(holder string) string {
+		idx, _ := strconv.Atoi(strings.Trim(holder, "{}"))
+		if idx < 1 || idx > len(params) {
+			return holder
+		}
+		return params[idx-1]
+	})
+}
+
+// ParamCount 统计模板需要的参数个数
+// 参数: tmplStr 模板内容
+// 返回: 最大占位符序号,如"{1}...{2}"返回2
+func ParamCount(tmplStr string) int {
+	count := 0
+	for _, match := range placeholderReg.FindAllStringSubmatch(tmplStr, -1) {
+		if idx, _ := strconv.Atoi(match[1]); idx > count {
+			count = idx
+		}
+	}
+	return count
+}
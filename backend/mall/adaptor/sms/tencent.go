@@ -0,0 +1,178 @@
+// Package sms 短信平台适配层-腾讯云
+// 职责: 调用腾讯云短信SendSms接口(API 3.0,TC3-HMAC-SHA256签名)
+package sms
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mall/config"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	tencentHost    = "sms.tencentcloudapi.com"
+	tencentService = "sms"
+	tencentAction  = "SendSms"
+	tencentVersion = "2021-01-11"
+	tencentRegion  = "ap-guangzhou" // 默认地域
+)
+
+// tencentResp 腾讯云SendSms响应
+type tencentResp struct {
+	Response struct {
+		SendStatusSet []struct {
+			Code    string `json:"Code"`
+			Message string `json:"Message"`
+		} `json:"SendStatusSet"`
+		Error *struct {
+			Code    string `json:"Code"`
+			Message string `json:"Message"`
+		} `json:"Error"`
+		RequestID string `json:"RequestId"`
+	} `json:"Response"`
+}
+
+// Tencent 腾讯云短信实现
+type Tencent struct {
+	conf   config.TencentSms // 腾讯云短信配置
+	client *http.Client      // HTTP客户端
+}
+
+// NewTencent 创建腾讯云短信实例
+// 参数: conf 腾讯云短信配置
+// 返回: Tencent实例
+// 调用链: NewSender -> NewTencent
+func NewTencent(conf config.TencentSms) *Tencent {
+	if conf.Region == "" {
+		conf.Region = tencentRegion
+	}
+	return &Tencent{
+		conf:   conf,
+		client: &http.Client{Timeout: httpTimeout},
+	}
+}
+
+// Send 发送短信
+// 参数:
+//   - ctx: 上下文
+//   - msg: 待发送的短信
+//
+// 返回: 接口调用失败或平台返回非Ok状态时返回错误
+func (t *Tencent) Send(ctx context.Context, msg *Message) error {
+	if t.conf.SecretID == "" || t.conf.SecretKey == "" || t.conf.SdkAppID == "" {
+		return errors.New("sms: tencent is not configured")
+	}
+
+	// 腾讯云要求E.164格式手机号,国内号码补+86
+	mobile := msg.Mobile
+	if !strings.HasPrefix(mobile, "+") {
+		mobile = "+86" + mobile
+	}
+	params := msg.Params
+	if params == nil {
+		params = []string{}
+	}
+	payload, err := json.Marshal(map[string]any{
+		"PhoneNumberSet":   []string{mobile},
+		"SmsSdkAppId":      t.conf.SdkAppID,
+		"SignName":         msg.SignName,
+		"TemplateId":       msg.TemplateID,
+		"TemplateParamSet": params,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://"+tencentHost, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Authorization", t.authorization(payload, timestamp))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Host", tencentHost)
+	req.Header.Set("X-TC-Action", tencentAction)
+	req.Header.Set("X-TC-Timestamp", strconv.FormatInt(timestamp, 10))
+	req.Header.Set("X-TC-Version", tencentVersion)
+	req.Header.Set("X-TC-Region", t.conf.Region)
+
+	httpResp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	resp := &tencentResp{}
+	if err = json.NewDecoder(httpResp.Body).Decode(resp); err != nil {
+		return fmt.Errorf("sms: tencent decode response: %w", err)
+	}
+	if resp.Response.Error != nil {
+		return fmt.Errorf("sms: tencent %s: %s", resp.Response.Error.Code, resp.Response.Error.Message)
+	}
+	for _, status := range resp.Response.SendStatusSet {
+		if status.Code != "Ok" {
+			return fmt.Errorf("sms: tencent %s: %s", status.Code, status.Message)
+		}
+	}
+	return nil
+}
+
+// authorization 生成TC3-HMAC-SHA256签名的Authorization头
+// 参数:
+//   - payload: 请求体
+//   - timestamp: 请求时间戳(秒),需与X-TC-Timestamp一致
+//
+// 返回: Authorization头内容
+func (t *Tencent) authorization(payload []byte, timestamp int64) string {
+	date := time.Unix(timestamp, 0).UTC().Format("2006-01-02")
+
+	// 1. 拼接规范请求串
+	canonicalRequest := strings.Join([]string{
+		http.MethodPost,
+		"/",
+		"",
+		"content-type:application/json; charset=utf-8\nhost:" + tencentHost + "\n",
+		"content-type;host",
+		sha256Hex(payload),
+	}, "\n")
+
+	// 2. 拼接待签名字符串
+	credentialScope := date + "/" + tencentService + "/tc3_request"
+	stringToSign := strings.Join([]string{
+		"TC3-HMAC-SHA256",
+		strconv.FormatInt(timestamp, 10),
+		credentialScope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	// 3. 派生签名密钥并计算签名
+	secretDate := hmacSha256([]byte("TC3"+t.conf.SecretKey), date)
+	secretService := hmacSha256(secretDate, tencentService)
+	secretSigning := hmacSha256(secretService, "tc3_request")
+	signature := hex.EncodeToString(hmacSha256(secretSigning, stringToSign))
+
+	return fmt.Sprintf("TC3-HMAC-SHA256 Credential=%s/%s, SignedHeaders=content-type;host, Signature=%s",
+		t.conf.SecretID, credentialScope, signature)
+}
+
+// sha256Hex 计算SHA256并返回十六进制字符串
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hmacSha256 计算HMAC-SHA256
+func hmacSha256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
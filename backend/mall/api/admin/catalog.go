@@ -0,0 +1,147 @@
+// Package admin 管理后台API控制器-课程目录管理
+// 职责: 课程目录树的查询、新增、重命名、删除和拖拽移动接口处理
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"mall/api"
+	"mall/common"
+	"mall/service/dto"
+)
+
+// GetCatalogTree 获取课程目录树接口
+// 路由: GET /api/mall/admin/v1/catalog/tree
+// 参数: Query - goods_id(课程商品ID)
+// 返回: 目录树,子节点嵌套在children中
+// 认证: 需要Token
+// 调用链: router -> GetCatalogTree -> service.GetCatalogTree -> repo.ListCatalogs
+func (c *Ctrl) GetCatalogTree(ctx *gin.Context) {
+	// 1. 参数绑定(Query参数)
+	req := &dto.CatalogTreeReq{}
+	if err := ctx.BindQuery(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 2. 调用Service层查询
+	resp, errno := c.user.GetCatalogTree(ctx.Request.Context(), req)
+
+	// 3. 返回响应
+	api.WriteResp(ctx, resp, errno)
+}
+
+// CreateCatalog 新增目录节点接口
+// 路由: POST /api/mall/admin/v1/catalog/create
+// 参数: JSON Body - GoodsID、ParentID(不传或-1表示顶级)、Name、Sort
+// 返回: 新目录ID
+// 认证: 需要Token
+// 调用链: router -> CreateCatalog -> service.CreateCatalog -> repo.CreateCatalog
+func (c *Ctrl) CreateCatalog(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.CreateCatalogReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层创建目录
+	id, errno := c.user.CreateCatalog(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应(包含新目录ID)
+	api.WriteResp(ctx, map[string]int64{
+		"id": id,
+	}, errno)
+}
+
+// RenameCatalog 重命名目录节点接口
+// 路由: POST /api/mall/admin/v1/catalog/rename
+// 参数: JSON Body - ID、Name
+// 返回: 无
+// 认证: 需要Token
+// 调用链: router -> RenameCatalog -> service.RenameCatalog -> repo.RenameCatalog
+func (c *Ctrl) RenameCatalog(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.RenameCatalogReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层重命名
+	errno := c.user.RenameCatalog(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应
+	api.WriteResp(ctx, nil, errno)
+}
+
+// DeleteCatalog 删除目录节点接口
+// 路由: POST /api/mall/admin/v1/catalog/delete
+// 参数: JSON Body - ID
+// 返回: 无
+// 认证: 需要Token
+// 限制: 目录下有子目录或课时时不能删除
+// 调用链: router -> DeleteCatalog -> service.DeleteCatalog -> repo.DeleteCatalog
+func (c *Ctrl) DeleteCatalog(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.CatalogIDReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层删除目录
+	errno := c.user.DeleteCatalog(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应
+	api.WriteResp(ctx, nil, errno)
+}
+
+// MoveCatalogs 批量移动/排序目录节点接口
+// 路由: POST /api/mall/admin/v1/catalog/move
+// 参数: JSON Body - GoodsID、Items(每项包含ID、ParentID、Sort)
+// 返回: 无
+// 认证: 需要Token
+// 特性: 层级由服务端重新计算,全部节点在同一事务中更新
+// 调用链: router -> MoveCatalogs -> service.MoveCatalogs -> repo.MoveCatalogs
+func (c *Ctrl) MoveCatalogs(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.MoveCatalogReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层移动目录
+	errno := c.user.MoveCatalogs(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应
+	api.WriteResp(ctx, nil, errno)
+}
@@ -0,0 +1,156 @@
+// Package admin 管理后台API控制器-课程商品管理
+// 职责: 课程商品的创建、编辑、列表、详情和上下架接口处理
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"mall/api"
+	"mall/common"
+	"mall/consts"
+	"mall/service/dto"
+)
+
+// ListGoods 分页查询课程商品接口
+// 路由: GET /api/mall/admin/v1/goods/list
+// 参数: Query - Page、PageSize、Name(名称模糊匹配)、SaleType(售卖类型)、ServiceTime(服务时长)、Status(上下架状态)
+// 返回: 商品列表及总条数
+// 认证: 需要Token
+// 调用链: router -> ListGoods -> service.ListGoods -> repo.ListGoods
+func (c *Ctrl) ListGoods(ctx *gin.Context) {
+	// 1. 参数绑定(Query参数)
+	req := &dto.ListGoodsReq{}
+	if err := ctx.BindQuery(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 2. 调用Service层查询
+	resp, errno := c.user.ListGoods(ctx.Request.Context(), req)
+
+	// 3. 返回响应
+	api.WriteResp(ctx, resp, errno)
+}
+
+// GetGoods 获取课程商品详情接口
+// 路由: GET /api/mall/admin/v1/goods/detail
+// 参数: Query - id(商品ID)
+// 返回: 商品详情
+// 认证: 需要Token
+// 调用链: router -> GetGoods -> service.GetGoods -> repo.GetGoods
+func (c *Ctrl) GetGoods(ctx *gin.Context) {
+	// 1. 参数绑定(Query参数)
+	req := &dto.GoodsIDReq{}
+	if err := ctx.BindQuery(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 2. 调用Service层查询
+	resp, errno := c.user.GetGoods(ctx.Request.Context(), req)
+
+	// 3. 返回响应
+	api.WriteResp(ctx, resp, errno)
+}
+
+// CreateGoods 创建课程商品接口
+// 路由: POST /api/mall/admin/v1/goods/create
+// 参数: JSON Body - Name、CoverKey、DetailCoverKey、Detail、CoursePrice(分)、ServiceTime、SaleType、Features
+// 返回: 新商品ID
+// 认证: 需要Token
+// 调用链: router -> CreateGoods -> service.CreateGoods -> repo.CreateGoods
+func (c *Ctrl) CreateGoods(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.CreateGoodsReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层创建商品
+	id, errno := c.user.CreateGoods(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应(包含新商品ID)
+	api.WriteResp(ctx, map[string]int64{
+		"id": id,
+	}, errno)
+}
+
+// UpdateGoods 编辑课程商品接口
+// 路由: POST /api/mall/admin/v1/goods/update
+// 参数: JSON Body - ID(商品ID)及CreateGoods的全部字段
+// 返回: 无
+// 认证: 需要Token
+// 调用链: router -> UpdateGoods -> service.UpdateGoods -> repo.UpdateGoods
+func (c *Ctrl) UpdateGoods(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.UpdateGoodsReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层更新商品
+	errno := c.user.UpdateGoods(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应
+	api.WriteResp(ctx, nil, errno)
+}
+
+// PublishGoods 上架课程商品接口
+// 路由: POST /api/mall/admin/v1/goods/publish
+// 参数: JSON Body - ID(商品ID)
+// 返回: 无
+// 认证: 需要Token
+// 限制: 必须有封面图、目录和至少一个课时
+// 调用链: router -> PublishGoods -> service.UpdateGoodsStatus
+func (c *Ctrl) PublishGoods(ctx *gin.Context) {
+	c.updateGoodsStatus(ctx, consts.GoodsOnShelf)
+}
+
+// UnpublishGoods 下架课程商品接口
+// 路由: POST /api/mall/admin/v1/goods/unpublish
+// 参数: JSON Body - ID(商品ID)
+// 返回: 无
+// 认证: 需要Token
+// 特性: 已购用户的权益不受影响
+// 调用链: router -> UnpublishGoods -> service.UpdateGoodsStatus
+func (c *Ctrl) UnpublishGoods(ctx *gin.Context) {
+	c.updateGoodsStatus(ctx, consts.GoodsOffShelf)
+}
+
+// updateGoodsStatus 上架/下架课程商品的公共处理
+func (c *Ctrl) updateGoodsStatus(ctx *gin.Context, status int32) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.GoodsIDReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层更新状态
+	errno := c.user.UpdateGoodsStatus(ctx.Request.Context(), user, req.ID, status)
+
+	// 4. 返回响应
+	api.WriteResp(ctx, nil, errno)
+}
@@ -0,0 +1,192 @@
+// Package admin 管理后台API控制器-课程课时管理
+// 职责: 课时的挂载、移除、排序、试听标记和定时可见接口处理
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"mall/api"
+	"mall/common"
+	"mall/consts"
+	"mall/service/dto"
+)
+
+// ListLessons 获取课程课时列表接口
+// 路由: GET /api/mall/admin/v1/lesson/list
+// 参数: Query - goods_id(课程商品ID)
+// 返回: 课时列表,包含未到可见时间的课时
+// 认证: 需要Token
+// 调用链: router -> ListLessons -> service.ListLessons -> repo.ListLessons
+func (c *Ctrl) ListLessons(ctx *gin.Context) {
+	// 1. 参数绑定(Query参数)
+	req := &dto.ListLessonReq{}
+	if err := ctx.BindQuery(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 2. 调用Service层查询
+	resp, errno := c.user.ListLessons(ctx.Request.Context(), req)
+
+	// 3. 返回响应
+	api.WriteResp(ctx, resp, errno)
+}
+
+// AttachLesson 挂载课时接口
+// 路由: POST /api/mall/admin/v1/lesson/attach
+// 参数: JSON Body - GoodsID、CatalogID、LessonID(录播课时ID)、EnableTrial、Sort、ShowTime(毫秒,不传表示立即可见)
+// 返回: 新课时记录ID
+// 认证: 需要Token
+// 调用链: router -> AttachLesson -> service.AttachLesson -> repo.CreateLesson
+func (c *Ctrl) AttachLesson(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.AttachLessonReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层挂载课时
+	id, errno := c.user.AttachLesson(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应(包含新课时记录ID)
+	api.WriteResp(ctx, map[string]int64{
+		"id": id,
+	}, errno)
+}
+
+// DetachLesson 移除课时接口
+// 路由: POST /api/mall/admin/v1/lesson/detach
+// 参数: JSON Body - ID(课时记录ID)
+// 返回: 无
+// 认证: 需要Token
+// 限制: 上架中的课程不能移除最后一个课时
+// 调用链: router -> DetachLesson -> service.DetachLesson -> repo.DeleteLesson
+func (c *Ctrl) DetachLesson(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.LessonIDReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层移除课时
+	errno := c.user.DetachLesson(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应
+	api.WriteResp(ctx, nil, errno)
+}
+
+// SortLessons 批量调整课时目录和排序接口
+// 路由: POST /api/mall/admin/v1/lesson/sort
+// 参数: JSON Body - GoodsID、Items(每项包含ID、CatalogID、Sort)
+// 返回: 无
+// 认证: 需要Token
+// 特性: 全部课时在同一事务中更新
+// 调用链: router -> SortLessons -> service.SortLessons -> repo.SortLessons
+func (c *Ctrl) SortLessons(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.SortLessonReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层调整排序
+	errno := c.user.SortLessons(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应
+	api.WriteResp(ctx, nil, errno)
+}
+
+// EnableLessonTrial 开启课时试听接口
+// 路由: POST /api/mall/admin/v1/lesson/trial/enable
+// 参数: JSON Body - ID(课时记录ID)
+// 返回: 无
+// 认证: 需要Token
+// 调用链: router -> EnableLessonTrial -> service.UpdateLessonTrial
+func (c *Ctrl) EnableLessonTrial(ctx *gin.Context) {
+	c.updateLessonTrial(ctx, consts.LessonTrialOn)
+}
+
+// DisableLessonTrial 关闭课时试听接口
+// 路由: POST /api/mall/admin/v1/lesson/trial/disable
+// 参数: JSON Body - ID(课时记录ID)
+// 返回: 无
+// 认证: 需要Token
+// 调用链: router -> DisableLessonTrial -> service.UpdateLessonTrial
+func (c *Ctrl) DisableLessonTrial(ctx *gin.Context) {
+	c.updateLessonTrial(ctx, consts.LessonTrialOff)
+}
+
+// updateLessonTrial 开启/关闭课时试听的公共处理
+func (c *Ctrl) updateLessonTrial(ctx *gin.Context, enableTrial int32) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.LessonIDReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层更新试听标记
+	errno := c.user.UpdateLessonTrial(ctx.Request.Context(), user, req.ID, enableTrial)
+
+	// 4. 返回响应
+	api.WriteResp(ctx, nil, errno)
+}
+
+// ScheduleLesson 设置课时可见时间接口
+// 路由: POST /api/mall/admin/v1/lesson/schedule
+// 参数: JSON Body - ID(课时记录ID)、ShowTime(毫秒时间戳)
+// 返回: 无
+// 认证: 需要Token
+// 特性: 可见时间之前用户端课时列表不返回该课时
+// 调用链: router -> ScheduleLesson -> service.ScheduleLesson -> repo.UpdateLessonShowTime
+func (c *Ctrl) ScheduleLesson(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.ScheduleLessonReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层设置可见时间
+	errno := c.user.ScheduleLesson(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应
+	api.WriteResp(ctx, nil, errno)
+}
@@ -1,11 +1,13 @@
 // Package admin 管理后台API控制器-登录模块
-// 职责: 验证码相关接口处理
+// 职责: 验证码、登录相关接口处理、Token解析
 package admin
 
 import (
+	"context"
 	"github.com/gin-gonic/gin"
 	"mall/api"
 	"mall/common"
+	"mall/consts"
 	"mall/service/dto"
 )
 
@@ -51,3 +53,156 @@ func (c *Ctrl) CheckSmsCodeCaptcha(ctx *gin.Context) {
 	// 3. 返回响应
 	api.WriteResp(ctx, resp, errno)
 }
+
+// MobilePasswordLogin 手机号+密码登录接口
+// 路由: POST /api/mall/admin/v1/user/mobile/password_login
+// 参数: JSON Body - Mobile(手机号)、Password(密码)、Ticket(滑块校验凭证)
+// 返回: Token、有效期、用户信息及上次登录信息
+// 白名单: 无需Token认证
+// 调用链: router -> MobilePasswordLogin -> service.MobilePasswordLogin
+func (c *Ctrl) MobilePasswordLogin(ctx *gin.Context) {
+	// 1. 参数绑定(JSON Body)
+	req := &dto.MobilePasswordLoginReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithErr(err))
+		return
+	}
+	req.ClientIP = ctx.ClientIP()
+
+	// 2. 调用Service层登录
+	resp, errno := c.user.MobilePasswordLogin(ctx.Request.Context(), req)
+
+	// 3. 返回响应
+	api.WriteResp(ctx, resp, errno)
+}
+
+// SendSmsCode 下发登录短信验证码接口
+// 路由: POST /api/mall/admin/v1/user/verify/smscode
+// 参数: JSON Body - Mobile(手机号)、Ticket(滑块校验凭证)
+// 返回: 验证码有效期(秒)
+// 白名单: 无需Token认证
+// 调用链: router -> SendSmsCode -> service.SendSmsCode
+func (c *Ctrl) SendSmsCode(ctx *gin.Context) {
+	// 1. 参数绑定(JSON Body)
+	req := &dto.SendSmsCodeReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithErr(err))
+		return
+	}
+
+	// 2. 调用Service层下发验证码
+	resp, errno := c.user.SendSmsCode(ctx.Request.Context(), consts.SmsSceneAdminLogin, req)
+
+	// 3. 返回响应
+	api.WriteResp(ctx, resp, errno)
+}
+
+// MobileVerifyLogin 手机号+短信验证码登录接口
+// 路由: POST /api/mall/admin/v1/user/mobile/verify_login
+// 参数: JSON Body - Mobile(手机号)、SmsCode(短信验证码)
+// 返回: Token、有效期、用户信息及上次登录信息
+// 白名单: 无需Token认证
+// 调用链: router -> MobileVerifyLogin -> service.MobileVerifyLogin
+func (c *Ctrl) MobileVerifyLogin(ctx *gin.Context) {
+	// 1. 参数绑定(JSON Body)
+	req := &dto.MobileVerifyLoginReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithErr(err))
+		return
+	}
+	req.ClientIP = ctx.ClientIP()
+
+	// 2. 调用Service层登录
+	resp, errno := c.user.MobileVerifyLogin(ctx.Request.Context(), req)
+
+	// 3. 返回响应
+	api.WriteResp(ctx, resp, errno)
+}
+
+// ParseToken 解析管理员Token
+// 参数:
+//   - ctx: 上下文
+//   - token: 请求头中的Token
+//
+// 返回: 管理员信息和错误
+// 用途: 作为router.TokenAdminFun注入AdminAuthMiddleware
+// 调用链: router.AdminAuthMiddleware -> ParseToken -> service.ParseToken
+func (c *Ctrl) ParseToken(ctx context.Context, token string) (*common.AdminUser, error) {
+	user, errno := c.user.ParseToken(ctx, token)
+	if !errno.IsOk() {
+		return nil, errno
+	}
+	return user, nil
+}
+
+// SendResetPasswordSmsCode 下发重置密码短信验证码接口
+// 路由: POST /api/mall/admin/v1/user/password/reset/smscode
+// 参数: JSON Body - Mobile(手机号)、Ticket(滑块校验凭证)
+// 返回: 验证码有效期(秒)
+// 白名单: 无需Token认证
+// 调用链: router -> SendResetPasswordSmsCode -> service.SendSmsCode
+func (c *Ctrl) SendResetPasswordSmsCode(ctx *gin.Context) {
+	// 1. 参数绑定(JSON Body)
+	req := &dto.SendSmsCodeReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithErr(err))
+		return
+	}
+
+	// 2. 调用Service层下发验证码
+	resp, errno := c.user.SendSmsCode(ctx.Request.Context(), consts.SmsSceneAdminResetPassword, req)
+
+	// 3. 返回响应
+	api.WriteResp(ctx, resp, errno)
+}
+
+// ResetPassword 重置密码接口
+// 路由: POST /api/mall/admin/v1/user/password/reset
+// 参数: JSON Body - Mobile(手机号)、SmsCode(短信验证码)、Password(新密码)
+// 返回: 无
+// 白名单: 无需Token认证
+// 特性: 重置成功后该管理员的全部登录会话失效
+// 调用链: router -> ResetPassword -> service.ResetPassword
+func (c *Ctrl) ResetPassword(ctx *gin.Context) {
+	// 1. 参数绑定(JSON Body)
+	req := &dto.ResetPasswordReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithErr(err))
+		return
+	}
+
+	// 2. 调用Service层重置密码
+	errno := c.user.ResetPassword(ctx.Request.Context(), req)
+
+	// 3. 返回响应
+	api.WriteResp(ctx, nil, errno)
+}
+
+// ChangePassword 修改密码接口
+// 路由: POST /api/mall/admin/v1/user/password/change
+// 参数: JSON Body - OldPassword(原密码)、NewPassword(新密码)
+// 返回: 无
+// 认证: 需要Token
+// 特性: 修改成功后除当前会话外的其他登录会话失效
+// 调用链: router -> ChangePassword -> service.ChangePassword
+func (c *Ctrl) ChangePassword(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.ChangePasswordReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithErr(err))
+		return
+	}
+
+	// 3. 调用Service层修改密码
+	errno := c.user.ChangePassword(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应
+	api.WriteResp(ctx, nil, errno)
+}
@@ -1 +1,213 @@
+// Package admin 管理后台API控制器-权限管理
+// 职责: 权限树的增删改查、启用禁用,当前管理员有效权限查询接口处理
 package admin
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"mall/api"
+	"mall/common"
+	"mall/consts"
+	"mall/service/dto"
+)
+
+// GetPermissionTree 获取完整权限树接口
+// 路由: GET /api/mall/admin/v1/perm/tree
+// 参数: 无
+// 返回: 权限树(菜单嵌套子菜单和操作,同级按Sort升序)
+// 认证: 需要Token
+// 调用链: router -> GetPermissionTree -> service.GetPermissionTree -> repo.ListAllPermissions
+func (c *Ctrl) GetPermissionTree(ctx *gin.Context) {
+	// 1. 调用Service层查询
+	tree, errno := c.user.GetPermissionTree(ctx.Request.Context())
+
+	// 2. 返回响应
+	api.WriteResp(ctx, tree, errno)
+}
+
+// CheckPermission 校验管理员权限
+// 参数:
+//   - ctx: 上下文
+//   - user: 当前登录的管理员
+//   - code: 接口要求的权限编码
+//
+// 返回: 错误,没有权限返回common.PermissionErr
+// 用途: 作为router.PermAdminFun注入AdminPermMiddleware
+// 调用链: router.AdminPermMiddleware -> CheckPermission -> service.CheckPermission
+func (c *Ctrl) CheckPermission(ctx context.Context, user *common.AdminUser, code string) error {
+	if errno := c.user.CheckPermission(ctx, user, code); !errno.IsOk() {
+		return errno
+	}
+	return nil
+}
+
+// SyncPermissions 同步路由声明的权限编码
+// 参数:
+//   - ctx: 上下文
+//   - routes: 声明了权限编码的路由
+//
+// 返回: 新增和孤立的权限编码,错误
+// 用途: 启动时或命令行模式下由router调用,不对外提供HTTP接口
+// 调用链: router.SyncPermissions -> SyncPermissions -> service.SyncPermissions
+func (c *Ctrl) SyncPermissions(ctx context.Context, routes []*dto.RoutePerm) (*dto.SyncPermissionResp, error) {
+	resp, errno := c.user.SyncPermissions(ctx, routes)
+	if !errno.IsOk() {
+		return nil, errno
+	}
+	return resp, nil
+}
+
+// GetMyPermissions 获取当前管理员的有效权限接口
+// 路由: GET /api/mall/admin/v1/user/perm
+// 参数: 无(从Token解析当前用户)
+// 返回: 有效权限编码列表和菜单树
+// 认证: 需要Token
+// 用途: 前端渲染侧边栏、控制按钮显示
+// 调用链: router -> GetMyPermissions -> service.GetMyPermissions
+func (c *Ctrl) GetMyPermissions(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 调用Service层查询
+	resp, errno := c.user.GetMyPermissions(ctx.Request.Context(), user)
+
+	// 3. 返回响应
+	api.WriteResp(ctx, resp, errno)
+}
+
+// CreatePermission 创建权限接口
+// 路由: POST /api/mall/admin/v1/perm/create
+// 参数: JSON Body - Code(编码)、Type(1菜单/2操作)、Name(名称)、PagePath(菜单路径)、ParentID(父级ID)、Sort(排序)、Desc(描述)
+// 返回: 新权限ID
+// 认证: 需要Token
+// 调用链: router -> CreatePermission -> service.CreatePermission -> repo.CreatePermission
+func (c *Ctrl) CreatePermission(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.CreatePermissionReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层创建权限
+	id, errno := c.user.CreatePermission(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应(包含新权限ID)
+	api.WriteResp(ctx, map[string]int64{
+		"id": id,
+	}, errno)
+}
+
+// UpdatePermission 更新权限接口
+// 路由: POST /api/mall/admin/v1/perm/update
+// 参数: JSON Body - ID(权限ID)及CreatePermission的全部字段,修改ParentID即移动节点
+// 返回: 无
+// 认证: 需要Token
+// 限制: 不能移动到自身或子孙节点下
+// 调用链: router -> UpdatePermission -> service.UpdatePermission -> repo.UpdatePermission
+func (c *Ctrl) UpdatePermission(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.UpdatePermissionReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层更新权限
+	errno := c.user.UpdatePermission(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应
+	api.WriteResp(ctx, nil, errno)
+}
+
+// EnablePermission 启用权限接口
+// 路由: POST /api/mall/admin/v1/perm/enable
+// 参数: JSON Body - ID(权限ID)
+// 返回: 无
+// 认证: 需要Token
+// 限制: 父级必须已启用
+// 调用链: router -> EnablePermission -> service.UpdatePermissionStatus
+func (c *Ctrl) EnablePermission(ctx *gin.Context) {
+	c.updatePermissionStatus(ctx, consts.IsEnable)
+}
+
+// DisablePermission 禁用权限接口
+// 路由: POST /api/mall/admin/v1/perm/disable
+// 参数: JSON Body - ID(权限ID)
+// 返回: 无
+// 认证: 需要Token
+// 特性: 子孙节点一并禁用
+// 调用链: router -> DisablePermission -> service.UpdatePermissionStatus
+func (c *Ctrl) DisablePermission(ctx *gin.Context) {
+	c.updatePermissionStatus(ctx, consts.IsDisable)
+}
+
+// updatePermissionStatus 启用/禁用权限的公共处理
+func (c *Ctrl) updatePermissionStatus(ctx *gin.Context, status int32) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.PermissionIDReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层更新状态
+	errno := c.user.UpdatePermissionStatus(ctx.Request.Context(), user, req.ID, status)
+
+	// 4. 返回响应
+	api.WriteResp(ctx, nil, errno)
+}
+
+// DeletePermission 删除权限接口
+// 路由: POST /api/mall/admin/v1/perm/delete
+// 参数: JSON Body - ID(权限ID)
+// 返回: 无
+// 认证: 需要Token
+// 限制: 有子权限时不能删除
+// 调用链: router -> DeletePermission -> service.DeletePermission -> repo.DeletePermission
+func (c *Ctrl) DeletePermission(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.PermissionIDReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层删除权限
+	errno := c.user.DeletePermission(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应
+	api.WriteResp(ctx, nil, errno)
+}
@@ -0,0 +1,90 @@
+// Package admin 管理后台API控制器-课程商品价格管理
+// 职责: 价格历史查询、未来价格排期和取消接口处理
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"mall/api"
+	"mall/common"
+	"mall/service/dto"
+)
+
+// ListGoodsPrices 获取课程商品价格历史接口
+// 路由: GET /api/mall/admin/v1/goods/price/list
+// 参数: Query - goods_id(课程商品ID)
+// 返回: 当前价格和价格记录列表(含未来排期,current标记当前生效的记录)
+// 认证: 需要Token
+// 调用链: router -> ListGoodsPrices -> service.ListGoodsPrices -> repo.ListPrices
+func (c *Ctrl) ListGoodsPrices(ctx *gin.Context) {
+	// 1. 参数绑定(Query参数)
+	req := &dto.GoodsPriceReq{}
+	if err := ctx.BindQuery(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 2. 调用Service层查询
+	resp, errno := c.user.ListGoodsPrices(ctx.Request.Context(), req)
+
+	// 3. 返回响应
+	api.WriteResp(ctx, resp, errno)
+}
+
+// ScheduleGoodsPrice 排期未来价格接口
+// 路由: POST /api/mall/admin/v1/goods/price/schedule
+// 参数: JSON Body - GoodsID、Price(分)、EffectiveFrom(毫秒)、EffectiveTo(毫秒,不传表示长期有效)
+// 返回: 新价格记录ID
+// 认证: 需要Token
+// 调用链: router -> ScheduleGoodsPrice -> service.ScheduleGoodsPrice -> repo.CreatePrice
+func (c *Ctrl) ScheduleGoodsPrice(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.ScheduleGoodsPriceReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层排期价格
+	id, errno := c.user.ScheduleGoodsPrice(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应(包含新价格记录ID)
+	api.WriteResp(ctx, map[string]int64{
+		"id": id,
+	}, errno)
+}
+
+// CancelGoodsPrice 取消价格排期接口
+// 路由: POST /api/mall/admin/v1/goods/price/cancel
+// 参数: JSON Body - ID(价格记录ID)
+// 返回: 无
+// 认证: 需要Token
+// 限制: 只能取消尚未生效的价格
+// 调用链: router -> CancelGoodsPrice -> service.CancelGoodsPrice -> repo.DeletePrice
+func (c *Ctrl) CancelGoodsPrice(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.GoodsPriceIDReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层取消排期
+	errno := c.user.CancelGoodsPrice(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应
+	api.WriteResp(ctx, nil, errno)
+}
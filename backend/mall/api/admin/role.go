@@ -1 +1,211 @@
+// Package admin 管理后台API控制器-角色管理
+// 职责: 角色的增删改查、启用禁用接口处理
 package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"mall/api"
+	"mall/common"
+	"mall/consts"
+	"mall/service/dto"
+)
+
+// ListRoles 分页查询角色列表接口
+// 路由: GET /api/mall/admin/v1/role/list
+// 参数: Query - Page、PageSize、Name(名称模糊匹配)、Status(状态)
+// 返回: 角色列表及总条数
+// 认证: 需要Token
+// 调用链: router -> ListRoles -> service.ListRoles -> repo.ListRoles
+func (c *Ctrl) ListRoles(ctx *gin.Context) {
+	// 1. 参数绑定(Query参数)
+	req := &dto.ListRoleReq{}
+	if err := ctx.BindQuery(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 2. 调用Service层查询
+	resp, errno := c.user.ListRoles(ctx.Request.Context(), req)
+
+	// 3. 返回响应
+	api.WriteResp(ctx, resp, errno)
+}
+
+// CreateRole 创建角色接口
+// 路由: POST /api/mall/admin/v1/role/create
+// 参数: JSON Body - Name(角色名称)、Desc(角色描述)
+// 返回: 新角色ID
+// 认证: 需要Token
+// 调用链: router -> CreateRole -> service.CreateRole -> repo.CreateRole
+func (c *Ctrl) CreateRole(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.CreateRoleReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层创建角色
+	id, errno := c.user.CreateRole(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应(包含新角色ID)
+	api.WriteResp(ctx, map[string]int64{
+		"id": id,
+	}, errno)
+}
+
+// UpdateRole 更新角色接口
+// 路由: POST /api/mall/admin/v1/role/update
+// 参数: JSON Body - ID(角色ID)、Name(角色名称)、Desc(角色描述)
+// 返回: 无
+// 认证: 需要Token
+// 调用链: router -> UpdateRole -> service.UpdateRole -> repo.UpdateRole
+func (c *Ctrl) UpdateRole(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.UpdateRoleReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层更新角色
+	errno := c.user.UpdateRole(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应
+	api.WriteResp(ctx, nil, errno)
+}
+
+// EnableRole 启用角色接口
+// 路由: POST /api/mall/admin/v1/role/enable
+// 参数: JSON Body - ID(角色ID)
+// 返回: 无
+// 认证: 需要Token
+// 调用链: router -> EnableRole -> service.UpdateRoleStatus
+func (c *Ctrl) EnableRole(ctx *gin.Context) {
+	c.updateRoleStatus(ctx, consts.IsEnable)
+}
+
+// DisableRole 禁用角色接口
+// 路由: POST /api/mall/admin/v1/role/disable
+// 参数: JSON Body - ID(角色ID)
+// 返回: 无
+// 认证: 需要Token
+// 调用链: router -> DisableRole -> service.UpdateRoleStatus
+func (c *Ctrl) DisableRole(ctx *gin.Context) {
+	c.updateRoleStatus(ctx, consts.IsDisable)
+}
+
+// updateRoleStatus 启用/禁用角色的公共处理
+func (c *Ctrl) updateRoleStatus(ctx *gin.Context, status int32) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.RoleIDReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层更新状态
+	errno := c.user.UpdateRoleStatus(ctx.Request.Context(), user, req.ID, status)
+
+	// 4. 返回响应
+	api.WriteResp(ctx, nil, errno)
+}
+
+// DeleteRole 删除角色接口
+// 路由: POST /api/mall/admin/v1/role/delete
+// 参数: JSON Body - ID(角色ID)
+// 返回: 无
+// 认证: 需要Token
+// 限制: 已分配给管理员的角色、超级管理员角色不能删除
+// 调用链: router -> DeleteRole -> service.DeleteRole -> repo.DeleteRole
+func (c *Ctrl) DeleteRole(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.RoleIDReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层删除角色
+	errno := c.user.DeleteRole(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应
+	api.WriteResp(ctx, nil, errno)
+}
+
+// GetRolePermissions 查询角色权限接口
+// 路由: GET /api/mall/admin/v1/role/perm
+// 参数: Query - id(角色ID)
+// 返回: 权限ID列表
+// 认证: 需要Token
+// 调用链: router -> GetRolePermissions -> service.GetRolePermissions -> repo.ListPermissionIDsByRoles
+func (c *Ctrl) GetRolePermissions(ctx *gin.Context) {
+	// 1. 参数绑定(Query参数)
+	req := &dto.RolePermissionReq{}
+	if err := ctx.BindQuery(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 2. 调用Service层查询
+	resp, errno := c.user.GetRolePermissions(ctx.Request.Context(), req)
+
+	// 3. 返回响应
+	api.WriteResp(ctx, resp, errno)
+}
+
+// UpdateRolePermissions 设置角色权限接口
+// 路由: POST /api/mall/admin/v1/role/perm/update
+// 参数: JSON Body - ID(角色ID)、PermissionIDs(最终拥有的全部权限ID)
+// 返回: 无
+// 认证: 需要Token
+// 调用链: router -> UpdateRolePermissions -> service.UpdateRolePermissions -> repo.ReplaceRolePermissions
+func (c *Ctrl) UpdateRolePermissions(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.UpdateRolePermissionReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层替换角色权限
+	errno := c.user.UpdateRolePermissions(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应
+	api.WriteResp(ctx, nil, errno)
+}
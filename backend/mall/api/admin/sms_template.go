@@ -0,0 +1,161 @@
+// Package admin 管理后台API控制器-短信模板管理
+// 职责: 短信模板的增删改查、启用禁用和测试发送接口处理
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"mall/api"
+	"mall/common"
+	"mall/consts"
+	"mall/service/dto"
+)
+
+// ListSmsTemplates 查询短信模板列表接口
+// 路由: GET /api/mall/admin/v1/sms/template/list
+// 参数: Query - SceneCode(场景编码)、Platform(平台)、Status(状态),均可选
+// 返回: 模板列表
+// 认证: 需要Token
+// 调用链: router -> ListSmsTemplates -> service.ListSmsTemplates
+func (c *Ctrl) ListSmsTemplates(ctx *gin.Context) {
+	// 1. 参数绑定(Query参数)
+	req := &dto.ListSmsTemplateReq{}
+	if err := ctx.BindQuery(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 2. 调用Service层查询
+	resp, errno := c.user.ListSmsTemplates(ctx.Request.Context(), req)
+
+	// 3. 返回响应
+	api.WriteResp(ctx, resp, errno)
+}
+
+// CreateSmsTemplate 创建短信模板接口
+// 路由: POST /api/mall/admin/v1/sms/template/create
+// 参数: JSON Body - SceneCode、SignName、PlatformTmplID、TmplStr、Platform
+// 返回: 新模板ID
+// 认证: 需要Token
+// 调用链: router -> CreateSmsTemplate -> service.CreateSmsTemplate
+func (c *Ctrl) CreateSmsTemplate(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.CreateSmsTemplateReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层创建模板
+	id, errno := c.user.CreateSmsTemplate(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应(包含新模板ID)
+	api.WriteResp(ctx, map[string]int64{
+		"id": id,
+	}, errno)
+}
+
+// UpdateSmsTemplate 更新短信模板接口
+// 路由: POST /api/mall/admin/v1/sms/template/update
+// 参数: JSON Body - ID、SceneCode、SignName、PlatformTmplID、TmplStr、Platform
+// 返回: 无
+// 认证: 需要Token
+// 调用链: router -> UpdateSmsTemplate -> service.UpdateSmsTemplate
+func (c *Ctrl) UpdateSmsTemplate(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.UpdateSmsTemplateReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层更新模板
+	errno := c.user.UpdateSmsTemplate(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应
+	api.WriteResp(ctx, nil, errno)
+}
+
+// EnableSmsTemplate 启用短信模板接口
+// 路由: POST /api/mall/admin/v1/sms/template/enable
+// 参数: JSON Body - ID(模板ID)
+// 返回: 无
+// 认证: 需要Token
+// 调用链: router -> EnableSmsTemplate -> service.UpdateSmsTemplateStatus
+func (c *Ctrl) EnableSmsTemplate(ctx *gin.Context) {
+	c.updateSmsTemplateStatus(ctx, consts.IsEnable)
+}
+
+// DisableSmsTemplate 禁用短信模板接口
+// 路由: POST /api/mall/admin/v1/sms/template/disable
+// 参数: JSON Body - ID(模板ID)
+// 返回: 无
+// 认证: 需要Token
+// 调用链: router -> DisableSmsTemplate -> service.UpdateSmsTemplateStatus
+func (c *Ctrl) DisableSmsTemplate(ctx *gin.Context) {
+	c.updateSmsTemplateStatus(ctx, consts.IsDisable)
+}
+
+// updateSmsTemplateStatus 启用/禁用短信模板的公共处理
+func (c *Ctrl) updateSmsTemplateStatus(ctx *gin.Context, status int32) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.SmsTemplateIDReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层更新状态
+	errno := c.user.UpdateSmsTemplateStatus(ctx.Request.Context(), user, req.ID, status)
+
+	// 4. 返回响应
+	api.WriteResp(ctx, nil, errno)
+}
+
+// SendTestSms 发送测试短信接口
+// 路由: POST /api/mall/admin/v1/sms/template/send_test
+// 参数: JSON Body - ID(模板ID)、Mobile(接收手机号)、Params(示例参数)
+// 返回: 渲染后的短信内容
+// 认证: 需要Token
+// 调用链: router -> SendTestSms -> service.SendTestSms
+func (c *Ctrl) SendTestSms(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.SendTestSmsReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层发送
+	resp, errno := c.user.SendTestSms(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应
+	api.WriteResp(ctx, resp, errno)
+}
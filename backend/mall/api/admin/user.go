@@ -24,7 +24,7 @@ func (c *Ctrl) GetUserInfo(ctx *gin.Context) {
 	}
 
 	// 2. 调用Service层获取用户信息
-	resp, errno := c.user.GetUserInfo(ctx.Request.Context(), &common.AdminUser{})
+	resp, errno := c.user.GetUserInfo(ctx.Request.Context(), user)
 
 	// 3. 返回响应
 	api.WriteResp(ctx, resp, errno)
@@ -97,7 +97,8 @@ func (c *Ctrl) UpdateUser(ctx *gin.Context) {
 // 返回: 无
 // 认证: 需要Token
 // 权限: 需要用户管理权限(TODO)
-// 用途: 启用或停用管理员账号
+// 用途: 启用或停用管理员账号,禁用后该管理员的Token立即失效
+// 限制: 不能禁用自己,不能禁用最后一个启用中的超级管理员
 // 调用链: router -> UpdateUserStatus -> service.UpdateUserStatus -> repo.UpdateUserStatus
 func (c *Ctrl) UpdateUserStatus(ctx *gin.Context) {
 	// 1. 从Context获取当前登录用户
@@ -120,3 +121,135 @@ func (c *Ctrl) UpdateUserStatus(ctx *gin.Context) {
 	// 4. 返回响应
 	api.WriteResp(ctx, nil, errno)
 }
+
+// ListUsers 分页查询管理员用户列表接口
+// 路由: GET /api/mall/admin/v1/user/list
+// 参数: Query - Page、PageSize、Name(姓名/昵称模糊匹配)、Mobile、Status、Sex、
+// CreateStart/CreateEnd(创建时间范围,毫秒时间戳)、OrderBy(create_at/update_at)、Order(asc/desc)
+// 返回: 用户列表及总条数
+// 认证: 需要Token
+// 权限: 需要用户管理权限(TODO)
+// 调用链: router -> ListUsers -> service.ListUsers -> repo.ListUsers
+func (c *Ctrl) ListUsers(ctx *gin.Context) {
+	// 1. 参数绑定(Query参数)
+	req := &dto.ListUserReq{}
+	if err := ctx.BindQuery(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 2. 调用Service层查询
+	resp, errno := c.user.ListUsers(ctx.Request.Context(), req)
+
+	// 3. 返回响应
+	api.WriteResp(ctx, resp, errno)
+}
+
+// DeleteUser 删除管理员用户接口
+// 路由: POST /api/mall/admin/v1/user/delete
+// 参数: JSON Body - ID(用户ID)
+// 返回: 无
+// 认证: 需要Token
+// 用途: 软删除管理员,删除后该管理员的Token立即失效,手机号可被新管理员使用
+// 限制: 不能删除自己,不能删除最后一个启用中的超级管理员
+// 调用链: router -> DeleteUser -> service.DeleteUser -> repo.DeleteUser
+func (c *Ctrl) DeleteUser(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.UserIDReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层删除用户
+	errno := c.user.DeleteUser(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应
+	api.WriteResp(ctx, nil, errno)
+}
+
+// RestoreUser 恢复已删除的管理员用户接口
+// 路由: POST /api/mall/admin/v1/user/restore
+// 参数: JSON Body - ID(用户ID)
+// 返回: 无
+// 认证: 需要Token
+// 权限: 仅超级管理员
+// 调用链: router -> RestoreUser -> service.RestoreUser -> repo.RestoreUser
+func (c *Ctrl) RestoreUser(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.UserIDReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层恢复用户
+	errno := c.user.RestoreUser(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应
+	api.WriteResp(ctx, nil, errno)
+}
+
+// GetUserRoles 查询管理员分配的角色接口
+// 路由: GET /api/mall/admin/v1/user/role
+// 参数: Query - id(管理员ID)
+// 返回: 角色ID列表(含已禁用的角色)
+// 认证: 需要Token
+// 调用链: router -> GetUserRoles -> service.GetUserRoles -> repo.ListRoleIDsByUser
+func (c *Ctrl) GetUserRoles(ctx *gin.Context) {
+	// 1. 参数绑定(Query参数)
+	req := &dto.UserRoleReq{}
+	if err := ctx.BindQuery(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 2. 调用Service层查询
+	resp, errno := c.user.GetUserRoles(ctx.Request.Context(), req)
+
+	// 3. 返回响应
+	api.WriteResp(ctx, resp, errno)
+}
+
+// UpdateUserRoles 分配管理员角色接口
+// 路由: POST /api/mall/admin/v1/user/role/update
+// 参数: JSON Body - ID(管理员ID)、RoleIDs(最终拥有的全部角色ID)
+// 返回: 无
+// 认证: 需要Token
+// 权限: 授予或收回超级管理员角色仅超级管理员
+// 调用链: router -> UpdateUserRoles -> service.UpdateUserRoles -> repo.ReplaceUserRoles
+func (c *Ctrl) UpdateUserRoles(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetAdminUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.UpdateUserRoleReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层分配角色
+	errno := c.user.UpdateUserRoles(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应
+	api.WriteResp(ctx, nil, errno)
+}
@@ -0,0 +1,73 @@
+// Package customer 用户前台API控制器-课程
+// 职责: 课程列表、搜索、详情和课时查询接口处理
+package customer
+
+import (
+	"github.com/gin-gonic/gin"
+	"mall/api"
+	"mall/common"
+	"mall/service/dto"
+)
+
+// SearchGoods 分页查询课程接口
+// 路由: GET /api/mall/customer/course/list
+// 参数: Query - Page、PageSize、Keyword(名称/特色/详情模糊匹配)、SaleType(1免费 2收费)、MinPrice/MaxPrice(分)
+// 返回: 上架中的课程列表及总条数
+// 认证: 无需Token(白名单)
+// 调用链: router -> SearchGoods -> service.SearchGoods -> repo.SearchGoods
+func (c *Ctrl) SearchGoods(ctx *gin.Context) {
+	// 1. 参数绑定(Query参数)
+	req := &dto.SearchGoodsReq{}
+	if err := ctx.BindQuery(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 2. 调用Service层查询
+	resp, errno := c.user.SearchGoods(ctx.Request.Context(), req)
+
+	// 3. 返回响应
+	api.WriteResp(ctx, resp, errno)
+}
+
+// GetCourseDetail 获取课程详情接口
+// 路由: GET /api/mall/customer/course/detail
+// 参数: Query - id(课程商品ID)
+// 返回: 商品信息、目录树和试听课时
+// 认证: 无需Token(白名单)
+// 调用链: router -> GetCourseDetail -> service.GetCourseDetail
+func (c *Ctrl) GetCourseDetail(ctx *gin.Context) {
+	// 1. 参数绑定(Query参数)
+	req := &dto.GoodsIDReq{}
+	if err := ctx.BindQuery(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 2. 调用Service层查询
+	resp, errno := c.user.GetCourseDetail(ctx.Request.Context(), req)
+
+	// 3. 返回响应
+	api.WriteResp(ctx, resp, errno)
+}
+
+// ListCourseLessons 获取课程课时列表接口
+// 路由: GET /api/mall/customer/course/lesson/list
+// 参数: Query - goods_id(课程商品ID)
+// 返回: 已到可见时间的课时列表,trial标记是否可试听
+// 认证: 无需Token(白名单)
+// 调用链: router -> ListCourseLessons -> service.ListCourseLessons -> repo.ListVisibleLessons
+func (c *Ctrl) ListCourseLessons(ctx *gin.Context) {
+	// 1. 参数绑定(Query参数)
+	req := &dto.CourseLessonReq{}
+	if err := ctx.BindQuery(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 2. 调用Service层查询
+	resp, errno := c.user.ListCourseLessons(ctx.Request.Context(), req)
+
+	// 3. 返回响应
+	api.WriteResp(ctx, resp, errno)
+}
@@ -1,15 +1,25 @@
+// Package customer 用户前台API控制器
+// 职责: 处理HTTP请求,参数绑定,调用Service层,返回统一响应
 package customer
 
 import (
 	"mall/adaptor"
+	"mall/service/user"
 )
 
+// Ctrl 用户前台控制器
 type Ctrl struct {
-	adaptor adaptor.IAdaptor
+	adaptor adaptor.IAdaptor // 适配器(预留)
+	user    *user.Service    // 前台用户业务服务
 }
 
+// NewCtrl 创建用户前台控制器实例
+// 参数: adaptor 适配器,提供数据库和Redis访问
+// 返回: Ctrl实例
+// 调用链: router.NewRouter -> customer.NewCtrl
 func NewCtrl(adaptor adaptor.IAdaptor) *Ctrl {
 	return &Ctrl{
 		adaptor: adaptor,
+		user:    user.NewService(adaptor), // 初始化业务服务
 	}
 }
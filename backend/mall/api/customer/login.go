@@ -0,0 +1,32 @@
+// Package customer 用户前台API控制器-登录
+// 职责: 前台用户登录接口处理
+package customer
+
+import (
+	"github.com/gin-gonic/gin"
+	"mall/api"
+	"mall/common"
+	"mall/service/dto"
+)
+
+// MobilePasswordLogin 手机号+密码登录接口
+// 路由: POST /api/mall/customer/user/mobile/password_login
+// 参数: JSON Body - Mobile(手机号)、Password(密码)、Ticket(滑块校验凭证)
+// 返回: Token、有效期和用户信息
+// 白名单: 无需Token认证
+// 调用链: router -> MobilePasswordLogin -> service.MobilePasswordLogin
+func (c *Ctrl) MobilePasswordLogin(ctx *gin.Context) {
+	// 1. 参数绑定(JSON Body)
+	req := &dto.CustomerPasswordLoginReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithErr(err))
+		return
+	}
+	req.ClientIP = ctx.ClientIP()
+
+	// 2. 调用Service层登录
+	resp, errno := c.user.MobilePasswordLogin(ctx.Request.Context(), req)
+
+	// 3. 返回响应
+	api.WriteResp(ctx, resp, errno)
+}
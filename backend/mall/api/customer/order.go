@@ -0,0 +1,105 @@
+// Package customer 用户前台API控制器-订单
+// 职责: 用户下单、取消订单和确认收货接口处理,未支付订单自动取消任务入口
+package customer
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"mall/api"
+	"mall/common"
+	"mall/service/dto"
+)
+
+// PlaceOrder 用户下单接口
+// 路由: POST /api/mall/customer/order/create
+// 参数: JSON Body - GoodsIDs(课程商品ID列表)、UserRemark(用户备注)
+// 返回: 订单ID、订单号、订单金额和原价(分)、支付截止时间
+// 认证: 需要Token
+// 调用链: router -> PlaceOrder -> service.PlaceOrder -> repo.CreateOrder
+func (c *Ctrl) PlaceOrder(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.PlaceOrderReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层下单
+	resp, errno := c.user.PlaceOrder(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应
+	api.WriteResp(ctx, resp, errno)
+}
+
+// CancelOrder 用户取消订单接口
+// 路由: POST /api/mall/customer/order/cancel
+// 参数: JSON Body - ID(订单ID)、Reason(取消原因)
+// 返回: 无
+// 认证: 需要Token
+// 限制: 只能取消自己待支付的订单
+// 调用链: router -> CancelOrder -> service.CancelOrder -> orderstate.Transit
+func (c *Ctrl) CancelOrder(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.CancelOrderReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层取消订单
+	errno := c.user.CancelOrder(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应
+	api.WriteResp(ctx, nil, errno)
+}
+
+// ConfirmOrder 用户确认收货接口
+// 路由: POST /api/mall/customer/order/confirm
+// 参数: JSON Body - ID(订单ID)
+// 返回: 无
+// 认证: 需要Token
+// 限制: 只能确认自己已发货或已签收的订单
+// 调用链: router -> ConfirmOrder -> service.ConfirmOrder -> orderstate.Transit
+func (c *Ctrl) ConfirmOrder(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 参数绑定(JSON Body)
+	req := &dto.OrderIDReq{}
+	if err := ctx.BindJSON(req); err != nil {
+		api.WriteResp(ctx, nil, common.ParamErr.WithMsg(err.Error()))
+		return
+	}
+
+	// 3. 调用Service层确认收货
+	errno := c.user.ConfirmOrder(ctx.Request.Context(), user, req)
+
+	// 4. 返回响应
+	api.WriteResp(ctx, nil, errno)
+}
+
+// RunOrderTimeout 运行未支付订单自动取消任务
+// 参数: ctx 上下文,取消后任务退出
+// 用途: 启动时由router调用,不对外提供HTTP接口
+// 调用链: router.RunTasks -> RunOrderTimeout -> service.RunOrderTimeout
+func (c *Ctrl) RunOrderTimeout(ctx context.Context) {
+	c.user.RunOrderTimeout(ctx)
+}
@@ -1 +1,47 @@
+// Package customer 用户前台API控制器-用户
+// 职责: 用户信息接口处理、Token解析
 package customer
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"mall/api"
+	"mall/common"
+)
+
+// ParseToken 解析用户Token
+// 参数:
+//   - ctx: 上下文
+//   - token: 请求头中的Token
+//
+// 返回: 用户信息和错误
+// 用途: 作为router.TokenFun注入AuthMiddleware
+// 调用链: router.AuthMiddleware -> ParseToken -> service.ParseToken
+func (c *Ctrl) ParseToken(ctx context.Context, token string) (*common.User, error) {
+	user, errno := c.user.ParseToken(ctx, token)
+	if !errno.IsOk() {
+		return nil, errno
+	}
+	return user, nil
+}
+
+// GetUserInfo 获取当前用户信息接口
+// 路由: GET /api/mall/customer/user/info
+// 参数: 无(从Token解析当前用户)
+// 返回: 用户ID、昵称、性别、头像
+// 认证: 需要Token
+// 调用链: router -> GetUserInfo -> service.GetUserInfo -> repo.GetUserInfo
+func (c *Ctrl) GetUserInfo(ctx *gin.Context) {
+	// 1. 从Context获取当前登录用户
+	user := api.GetUserFromCtx(ctx)
+	if user == nil {
+		api.WriteResp(ctx, nil, common.AuthErr)
+		return
+	}
+
+	// 2. 调用Service层获取用户信息
+	resp, errno := c.user.GetUserInfo(ctx.Request.Context(), user)
+
+	// 3. 返回响应
+	api.WriteResp(ctx, resp, errno)
+}
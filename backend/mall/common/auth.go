@@ -5,8 +5,9 @@ package common
 // AdminUser 管理员用户信息
 // 用于认证中间件解析Token后存储到Context
 type AdminUser struct {
-	UserID int64  `json:"user_id"` // 管理员ID
-	Name   string `json:"name"`    // 管理员姓名
+	UserID  int64  `json:"user_id"`  // 管理员ID
+	Name    string `json:"name"`     // 管理员姓名
+	TokenID string `json:"token_id"` // 当前Token唯一ID(jti),用于会话管理
 }
 
 // User 前台用户信息
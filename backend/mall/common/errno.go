@@ -57,4 +57,43 @@ var (
 	// 业务错误码 (11000+)
 	UserNotFoundErr   = Errno{Code: 11001, Msg: "User Not Found"}
 	InvalidCaptchaErr = Errno{Code: 11002, Msg: "滑块校验失败，请重试"}
+	TokenInvalidErr   = Errno{Code: 11003, Msg: "登录凭证无效，请重新登录"}
+	TokenExpiredErr   = Errno{Code: 11004, Msg: "登录已过期，请重新登录"}
+	UserDisabledErr   = Errno{Code: 11005, Msg: "账号已被禁用"}
+	WrongPasswordErr  = Errno{Code: 11006, Msg: "手机号或密码错误"}
+	TicketExpiredErr  = Errno{Code: 11007, Msg: "安全验证已过期，请重新完成滑块验证"}
+	SmsCodeExpiredErr = Errno{Code: 11008, Msg: "验证码已失效，请重新获取"}
+	SmsCodeWrongErr   = Errno{Code: 11009, Msg: "验证码错误"}
+	SmsCodeLimitErr   = Errno{Code: 11010, Msg: "验证码错误次数过多，请稍后再试"}
+	SmsTemplateErr    = Errno{Code: 11011, Msg: "短信模板未配置"}
+	SmsSendErr        = Errno{Code: 11012, Msg: "短信发送失败，请稍后重试"}
+	DataNotFoundErr   = Errno{Code: 11013, Msg: "数据不存在"}
+	SmsSceneExistErr  = Errno{Code: 11014, Msg: "该场景已存在启用中的短信模板"}
+	PasswordWeakErr   = Errno{Code: 11015, Msg: "密码需为8-32位且同时包含字母和数字"}
+	PasswordSameErr   = Errno{Code: 11016, Msg: "新密码不能与原密码相同"}
+	DisableSelfErr    = Errno{Code: 11017, Msg: "不能禁用自己的账号"}
+	LastSuperAdminErr = Errno{Code: 11018, Msg: "至少需要保留一个启用中的超级管理员"}
+	DeleteSelfErr     = Errno{Code: 11019, Msg: "不能删除自己的账号"}
+	MobileExistErr    = Errno{Code: 11020, Msg: "手机号已被其他管理员使用"}
+	RoleNameExistErr  = Errno{Code: 11021, Msg: "角色名称已存在"}
+	RoleInUseErr      = Errno{Code: 11022, Msg: "角色已分配给管理员，请先解除分配"}
+	SuperAdminRoleErr = Errno{Code: 11023, Msg: "超级管理员角色不允许禁用或删除"}
+	PermCodeExistErr  = Errno{Code: 11024, Msg: "权限编码已存在"}
+	PermParentErr     = Errno{Code: 11025, Msg: "父级权限不存在或不是菜单"}
+	PermCycleErr      = Errno{Code: 11026, Msg: "不能将权限移动到自身或其子节点下"}
+	PermHasChildErr   = Errno{Code: 11027, Msg: "请先删除子权限"}
+	PermParentOffErr  = Errno{Code: 11028, Msg: "父级权限已禁用，请先启用父级"}
+	GoodsPublishErr   = Errno{Code: 11029, Msg: "课程信息不完整，不能上架"}
+	CatalogParentErr  = Errno{Code: 11030, Msg: "父级目录不存在"}
+	CatalogCycleErr   = Errno{Code: 11031, Msg: "不能将目录移动到自身或其子目录下"}
+	CatalogInUseErr   = Errno{Code: 11032, Msg: "请先删除子目录和课时"}
+	LessonExistErr    = Errno{Code: 11033, Msg: "该课时已挂载到课程"}
+	GoodsOffShelfErr  = Errno{Code: 11034, Msg: "商品不存在或已下架"}
+	GoodsOwnedErr     = Errno{Code: 11035, Msg: "已购买过该课程，无需重复购买"}
+	OrderStatusErr    = Errno{Code: 11036, Msg: "当前订单状态不允许该操作"}
+	OrderChangedErr   = Errno{Code: 11037, Msg: "订单状态已变化，请刷新后重试"}
+	SmsFrequentErr    = Errno{Code: 11038, Msg: "验证码发送过于频繁，请稍后再试"}
+	LoginLimitErr     = Errno{Code: 11039, Msg: "登录失败次数过多，请稍后再试"}
+	OrderPendingErr   = Errno{Code: 11040, Msg: "该课程已有待支付订单，请先支付或取消"}
+	OrderTradeErr     = Errno{Code: 11041, Msg: "订单已由其他交易支付"}
 )
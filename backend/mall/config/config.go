@@ -4,6 +4,7 @@
 package config
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"github.com/goccy/go-yaml"
@@ -31,6 +32,9 @@ type Config struct {
 	Server Server `yaml:"server"`
 	Mysql  Mysql  `yaml:"mysql"`
 	Redis  Redis  `yaml:"redis"`
+	Token  Token  `yaml:"token"`
+	Sms    Sms    `yaml:"sms"`
+	Order  Order  `yaml:"order"`
 }
 
 // Server HTTP服务器配置
@@ -39,6 +43,7 @@ type Server struct {
 	Env         string `yaml:"env"`          // 环境标识: dev/test/prod
 	EnablePprof bool   `yaml:"enable_pprof"` // 是否启用pprof性能分析
 	LogLevel    string `yaml:"log_level"`    // 日志级别: debug/info/warn/error
+	SyncPerm    bool   `yaml:"sync_perm"`    // 启动时是否将路由声明的权限编码同步到permission表
 }
 
 // Mysql 数据库配置
@@ -71,6 +76,84 @@ type Redis struct {
 	MaxOpen int    `yaml:"max_open"` // 最大活跃连接数
 }
 
+// Token 令牌配置
+// 管理后台与用户前台使用各自独立的签名密钥,互不通用
+type Token struct {
+	Admin    Jwt `yaml:"admin"`    // 管理后台Token配置
+	Customer Jwt `yaml:"customer"` // 用户前台Token配置
+}
+
+// Check 校验管理后台与用户前台的Token配置相互独立
+// 规则: 接收方必填且两侧不能相同,两侧不能使用相同的签名密钥
+// 原因: 接收方为空时不校验aud,密钥相同时一侧签发的Token可以在另一侧通过校验
+// 调用: main.main -> Check,校验失败时拒绝启动
+func (t *Token) Check() error {
+	if t.Admin.Audience == "" || t.Customer.Audience == "" {
+		return errors.New("token: audience is required for both admin and customer")
+	}
+	if t.Admin.Audience == t.Customer.Audience {
+		return errors.New("token: admin and customer must use different audiences")
+	}
+	if t.Admin.Secret != "" && t.Admin.Secret == t.Customer.Secret {
+		return errors.New("token: admin and customer must use different secrets")
+	}
+	if t.Admin.PublicKey != "" && t.Admin.PublicKey == t.Customer.PublicKey {
+		return errors.New("token: admin and customer must use different rsa keys")
+	}
+	return nil
+}
+
+// Jwt JWT签发与校验配置
+// 签名算法为HS256时使用Secret,为RS256时使用PrivateKey/PublicKey(PEM格式)
+type Jwt struct {
+	Method     string `yaml:"method"`      // 签名算法: HS256/RS256,默认HS256
+	Secret     string `yaml:"secret"`      // HS256签名密钥
+	PrivateKey string `yaml:"private_key"` // RS256私钥(PEM),用于签发
+	PublicKey  string `yaml:"public_key"`  // RS256公钥(PEM),用于校验
+	Issuer     string `yaml:"issuer"`      // 签发者,校验时必须一致
+	Audience   string `yaml:"audience"`    // 接收方,校验时必须一致
+	Expire     int64  `yaml:"expire"`      // 有效期(秒),默认7200
+}
+
+// Order 订单配置
+type Order struct {
+	PayTimeout int64 `yaml:"pay_timeout"` // 未支付订单自动取消时间(秒),默认1800
+}
+
+// GetPayTimeout 获取未支付订单自动取消时间
+// 未配置或配置值不合法时返回默认值30分钟
+func (o *Order) GetPayTimeout() time.Duration {
+	if o.PayTimeout <= 0 {
+		return 30 * time.Minute
+	}
+	return time.Duration(o.PayTimeout) * time.Second
+}
+
+// Sms 短信平台配置
+// 发送时按sms_template.platform选择平台,Fake开启后所有短信均走本地假通道
+type Sms struct {
+	Fake     bool        `yaml:"fake"`      // 是否强制使用本地假通道,本地开发和CI环境使用
+	FakeFile string      `yaml:"fake_file"` // 假通道发送记录文件(JSON Lines),为空时只输出日志
+	Tencent  TencentSms  `yaml:"tencent"`   // 腾讯云短信配置
+	Ronglian RonglianSms `yaml:"ronglian"`  // 容联云短信配置
+}
+
+// TencentSms 腾讯云短信配置
+type TencentSms struct {
+	SecretID  string `yaml:"secret_id"`  // 访问密钥ID
+	SecretKey string `yaml:"secret_key"` // 访问密钥
+	SdkAppID  string `yaml:"sdk_app_id"` // 短信应用ID
+	Region    string `yaml:"region"`     // 地域,默认ap-guangzhou
+}
+
+// RonglianSms 容联云短信配置
+type RonglianSms struct {
+	AccountSid string `yaml:"account_sid"` // 主账号ID
+	AuthToken  string `yaml:"auth_token"`  // 主账号令牌
+	AppID      string `yaml:"app_id"`      // 应用ID
+	Host       string `yaml:"host"`        // 接口地址,默认https://app.cloopen.com:8883
+}
+
 // init 初始化命令行参数
 // -c: 指定本地配置文件路径,默认mall_local.yml
 // -r: 指定etcd地址,默认从环境变量ETCD_ADDR获取
@@ -13,3 +13,107 @@ const (
 	IsEnable  = 1  // 启用状态
 	IsDisable = -1 // 禁用状态
 )
+
+const (
+	NotDeleted = 0 // 未删除
+	IsDeleted  = 1 // 已删除(软删除)
+)
+
+const (
+	SuperAdminRoleID   = 1   // 超级管理员角色ID,对应roles表初始化数据
+	SuperAdminPermCode = "*" // 保留的通配编码,不能用作权限编码
+)
+
+// 权限类型,与permission.type对应
+const (
+	PermTypeMenu      = 1 // 菜单
+	PermTypeOperation = 2 // 操作
+)
+
+const PermRootParentID = -1 // 顶级权限的父级ID
+
+const SystemOperatorID = -1 // 系统操作人ID,用于启动同步、定时任务等非管理员发起的写操作
+
+// 短信场景编码,与sms_template.scene_code对应,不同场景的验证码互不通用
+const (
+	SmsSceneAdminLogin         = "admin_login"          // 管理员验证码登录
+	SmsSceneAdminResetPassword = "admin_reset_password" // 管理员重置密码
+)
+
+// 课程商品售卖类型,与course_goods.sale_type对应
+const (
+	SaleTypeFree = 1 // 免费
+	SaleTypePaid = 2 // 收费
+)
+
+// 课程商品辅导服务时长,与course_goods.service_time对应
+const (
+	ServiceTimeOneMonth    = 1 // 一个月
+	ServiceTimeThreeMonths = 2 // 三个月
+	ServiceTimeHalfYear    = 3 // 半年
+	ServiceTimeOneYear     = 4 // 一年
+)
+
+// 课程商品上下架状态,与course_goods.status对应
+const (
+	GoodsOnShelf  = 1  // 上架
+	GoodsOffShelf = -1 // 下架
+)
+
+// 课程目录层级,与course_catalog.parent_id/level对应
+const (
+	CatalogRootParentID = -1 // 顶级目录的父级ID
+	CatalogRootLevel    = 1  // 顶级目录的层级
+)
+
+// 课时试听标记,与course_lessons.enable_trial对应
+const (
+	LessonTrialOn  = 1  // 可试听
+	LessonTrialOff = -1 // 不可试听
+)
+
+// 商品类型,与order_items.goods_type、user_course_goods.goods_type对应
+const (
+	GoodsTypeCourse = 1 // 课程商品
+)
+
+// 订单来源,与orders.order_source对应
+const (
+	OrderSourceUser   = 1 // 用户下单
+	OrderSourceAdmin  = 2 // 管理后台
+	OrderSourceSystem = 3 // 系统赠送
+)
+
+// 订单状态,与orders.status对应
+const (
+	OrderStatusCancelled = -1 // 已取消
+	OrderStatusPending   = 1  // 待支付
+	OrderStatusPaid      = 2  // 已支付(待发货)
+	OrderStatusRefunded  = 3  // 已退款
+	OrderStatusShipped   = 4  // 已发货
+	OrderStatusSigned    = 5  // 已签收
+	OrderStatusReceived  = 6  // 已收货
+)
+
+// 订单取消类型,与orders.cancel_type对应
+const (
+	OrderCancelByUser    = 1 // 用户取消
+	OrderCancelByService = 2 // 客服取消
+	OrderCancelByTimeout = 3 // 超时自动取消
+)
+
+// 订单确认收货类型,与orders.receiver_confirm_type对应
+const (
+	OrderConfirmByUser = 1  // 用户确认收货
+	OrderConfirmByAuto = 99 // 超过10天自动确认收货
+)
+
+// 订单状态变更操作人类型,与order_status_log.operator_type对应
+const (
+	OrderOperatorUser   = 1 // 用户
+	OrderOperatorAdmin  = 2 // 管理员
+	OrderOperatorSystem = 3 // 系统
+)
+
+// 单次下单的商品数量上限
+const MaxOrderGoods = 20
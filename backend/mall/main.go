@@ -1,9 +1,11 @@
 // Package main 应用程序入口
-// 职责: 初始化配置、数据库连接、Redis连接,启动HTTP服务器
+// 职责: 初始化配置、数据库连接、Redis连接,启动后台任务和HTTP服务器
 package main
 
 import (
+	"context"
 	"errors"
+	"flag"
 	"github.com/go-redis/redis"
 	"github.com/samber/lo"
 	"gorm.io/driver/mysql"
@@ -14,15 +16,20 @@ import (
 	"mall/utils/logger"
 )
 
+// syncPermOnly 命令行模式: 同步路由声明的权限编码后退出,不启动HTTP服务器
+var syncPermOnly = flag.Bool("sync_perm", false, "sync route permission codes into permission table and exit")
+
 // main 应用程序主入口
 // 执行流程:
-// 1. 初始化配置(支持本地文件和etcd)
+// 1. 初始化配置(支持本地文件和etcd),校验Token配置
 // 2. 设置日志级别
 // 3. 初始化MySQL连接
 // 4. 初始化Redis连接
-// 5. 启动HTTP服务器
+// 5. 注册路由,按需同步权限编码(-sync_perm或配置server.sync_perm)
+// 6. 启动后台任务和HTTP服务器(-sync_perm模式下直接退出),服务器关闭后停止后台任务
 func main() {
 	conf := config.InitConfig()
+	handleErr(conf.Token.Check())
 	logger.SetLevel(conf.Server.LogLevel)
 
 	dbClient, err := initMysql(&conf.Mysql)
@@ -33,35 +40,44 @@ func main() {
 	handleErr(err)
 	logger.Debug("client connect success")
 
-	startServer(conf, dbClient, rdsClient).Run()
+	r := newRouter(conf, dbClient, rdsClient)
+	app := router.NewApp(conf.Server.HttpPort, r)
+	if *syncPermOnly || conf.Server.SyncPerm {
+		handleErr(r.SyncPermissions(context.Background()))
+		if *syncPermOnly {
+			return
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r.RunTasks(ctx)
+	app.Run()
+	cancel()
 }
 
-// startServer 启动HTTP服务器
+// newRouter 创建路由器
 // 参数:
 //   - conf: 配置对象
 //   - db: GORM数据库连接
 //   - redis: Redis客户端
 //
-// 返回: router.App HTTP服务器实例
-// 调用链: main -> router.NewApp -> router.NewRouter -> adaptor.NewAdaptor
-func startServer(conf *config.Config, db *gorm.DB, redis *redis.Client) *router.App {
-	return router.NewApp(conf.Server.HttpPort,
-		router.NewRouter(
-			conf,
-			adaptor.NewAdaptor(conf, db, redis),
-			// 健康检查函数: 用于/ping接口检测MySQL和Redis连通性
-			func() error {
-				err := func() error {
-					pingDb, err := db.DB()
-					handleErr(err)
-					return pingDb.Ping()
-				}()
-				if err != nil {
-					return errors.New("mysql connect failed")
-				}
-				return redis.Ping().Err()
-			},
-		),
+// 返回: router.Router 路由器实例,由router.NewApp注册到HTTP服务器
+// 调用链: main -> router.NewRouter -> adaptor.NewAdaptor
+func newRouter(conf *config.Config, db *gorm.DB, redis *redis.Client) *router.Router {
+	return router.NewRouter(
+		conf,
+		adaptor.NewAdaptor(conf, db, redis),
+		// 健康检查函数: 用于/ping接口检测MySQL和Redis连通性
+		func() error {
+			err := func() error {
+				pingDb, err := db.DB()
+				handleErr(err)
+				return pingDb.Ping()
+			}()
+			if err != nil {
+				return errors.New("mysql connect failed")
+			}
+			return redis.Ping().Err()
+		},
 	)
 }
 
@@ -90,12 +106,14 @@ func initRedis(conf *config.Redis) (*redis.Client, error) {
 // 连接池配置:
 //   - MaxIdle: 最小值5,配置值+1
 //   - MaxOpen: 最小值10,配置值+1
+//
+// 错误转换: 开启TranslateError,唯一索引冲突返回gorm.ErrDuplicatedKey
 func initMysql(conf *config.Mysql) (*gorm.DB, error) {
 	// 确保连接池配置合理的最小值
 	conf.MaxIdle = lo.Max([]int{conf.MaxIdle + 1, 5})
 	conf.MaxOpen = lo.Max([]int{conf.MaxOpen + 1, 10})
 	dsn := conf.GetDsn()
-	db, err := gorm.Open(mysql.Open(dsn))
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		return nil, err
 	}
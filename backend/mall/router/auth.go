@@ -4,6 +4,7 @@ package router
 
 import (
 	"context"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"mall/common"
 	"mall/consts"
@@ -50,7 +51,7 @@ func AuthMiddleware(filter func(*gin.Context) bool, getTokenFun TokenFun) gin.Ha
 		// 解析Token获取用户信息
 		user, err := getTokenFun(ctx, token)
 		if err != nil {
-			ctx.JSON(http.StatusUnauthorized, common.AuthErr.WithErr(err))
+			ctx.JSON(http.StatusUnauthorized, authErrno(err))
 			ctx.Abort()
 			return
 		}
@@ -91,7 +92,7 @@ func AdminAuthMiddleware(filter func(*gin.Context) bool, getTokenFun TokenAdminF
 		// 解析Token获取管理员信息
 		user, err := getTokenFun(ctx, token)
 		if err != nil {
-			ctx.JSON(http.StatusUnauthorized, common.AuthErr.WithErr(err))
+			ctx.JSON(http.StatusUnauthorized, authErrno(err))
 			ctx.Abort()
 			return
 		}
@@ -101,3 +102,15 @@ func AdminAuthMiddleware(filter func(*gin.Context) bool, getTokenFun TokenAdminF
 		ctx.Next()
 	}
 }
+
+// authErrno 将Token解析错误转换为错误码
+// 参数: err Token解析函数返回的错误
+// 返回: 业务错误码(如Token过期、账号禁用)原样返回,其他错误包装为AuthErr
+// 用途: 让前端能区分过期、禁用等情况并给出具体提示
+func authErrno(err error) common.Errno {
+	var errno common.Errno
+	if errors.As(err, &errno) {
+		return errno
+	}
+	return common.AuthErr.WithErr(err)
+}
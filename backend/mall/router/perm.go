@@ -0,0 +1,121 @@
+// Package router 路由层-权限中间件
+// 职责: 管理后台接口权限声明与校验
+// 用法: 需要权限的路由通过permGroup注册,注册时声明所需的权限编码(对应permission.code)
+// 同步: SyncPermissions将声明的权限编码同步到permission表
+package router
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"mall/api"
+	"mall/common"
+	"mall/service/dto"
+	"mall/utils/logger"
+	"net/http"
+)
+
+// PermAdminFun 管理员权限校验函数类型
+// 参数: context、当前管理员和权限编码
+// 返回: 没有权限时返回common.PermissionErr
+type PermAdminFun func(ctx context.Context, user *common.AdminUser, code string) error
+
+// PermRoute 声明了权限编码的路由
+type PermRoute struct {
+	Method string // 请求方法
+	Path   string // 完整路由路径
+	Code   string // 所需权限编码
+}
+
+// AdminPermMiddleware 管理后台权限中间件
+// 参数:
+//   - code: 接口要求的权限编码
+//   - checkFun: 权限校验函数
+//
+// 返回: Gin中间件函数
+// 前置: 需在AdminAuthMiddleware之后执行,从Context中读取管理员信息
+// 响应:
+//   - 未登录: 401 AuthErr
+//   - 没有权限: 403 PermissionErr
+//   - 其他错误(如数据库异常): 500
+func AdminPermMiddleware(code string, checkFun PermAdminFun) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		user := api.GetAdminUserFromCtx(ctx)
+		if user == nil {
+			ctx.JSON(http.StatusUnauthorized, common.AuthErr)
+			ctx.Abort()
+			return
+		}
+
+		if err := checkFun(ctx, user, code); err != nil {
+			var errno common.Errno
+			if !errors.As(err, &errno) {
+				errno = common.ServerErr.WithErr(err)
+			}
+			status := http.StatusInternalServerError
+			if errno.Code == common.PermissionErr.Code {
+				status = http.StatusForbidden
+			}
+			ctx.JSON(status, errno)
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// permGroup 声明权限的路由分组
+// 通过GET/POST注册的路由都会挂载AdminPermMiddleware,并记录到routes中
+type permGroup struct {
+	group  *gin.RouterGroup // 所属路由分组
+	check  PermAdminFun     // 权限校验函数
+	routes *[]PermRoute     // 已声明权限的路由
+}
+
+// handle 注册路由并声明所需权限
+func (g *permGroup) handle(method, path, code string, handler gin.HandlerFunc) {
+	*g.routes = append(*g.routes, PermRoute{
+		Method: method,
+		Path:   g.group.BasePath() + path,
+		Code:   code,
+	})
+	g.group.Handle(method, path, AdminPermMiddleware(code, g.check), handler)
+}
+
+// GET 注册需要权限的GET路由
+func (g *permGroup) GET(path, code string, handler gin.HandlerFunc) {
+	g.handle(http.MethodGet, path, code, handler)
+}
+
+// POST 注册需要权限的POST路由
+func (g *permGroup) POST(path, code string, handler gin.HandlerFunc) {
+	g.handle(http.MethodPost, path, code, handler)
+}
+
+// SyncPermissions 将路由声明的权限编码同步到permission表
+// 参数: ctx 上下文
+// 返回: 错误信息
+// 前置: 需在Register之后调用,此时才收集到全部路由
+// 特性: 缺少的编码新增为操作权限,没有路由声明的操作权限只记录告警日志
+// 调用链: main.main -> SyncPermissions -> admin.SyncPermissions
+func (r *Router) SyncPermissions(ctx context.Context) error {
+	routes := make([]*dto.RoutePerm, 0, len(r.adminPerms))
+	for _, route := range r.adminPerms {
+		routes = append(routes, &dto.RoutePerm{
+			Method: route.Method,
+			Path:   route.Path,
+			Code:   route.Code,
+		})
+	}
+	resp, err := r.admin.SyncPermissions(ctx, routes)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("sync permissions done", zap.Int("routes", len(routes)), zap.Strings("created", resp.Created))
+	if len(resp.Orphaned) > 0 {
+		logger.Warn("permissions not declared by any route", zap.Strings("orphaned", resp.Orphaned))
+	}
+	return nil
+}
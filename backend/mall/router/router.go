@@ -4,12 +4,10 @@
 package router
 
 import (
-	"context"
 	"github.com/gin-gonic/gin"
 	"mall/adaptor"
 	"mall/api/admin"
 	"mall/api/customer"
-	"mall/common"
 	"mall/config"
 	"net/http"
 	"strings"
@@ -30,6 +28,7 @@ type Router struct {
 	checkFunc func() error    // 健康检查函数(MySQL+Redis连接测试)
 	admin     *admin.Ctrl     // 管理后台控制器
 	customer  *customer.Ctrl  // 用户前台控制器
+	adminPerms []PermRoute    // 管理后台声明了权限编码的路由
 }
 
 // NewRouter 创建路由器实例
@@ -120,41 +119,174 @@ func (r *Router) route(root *gin.RouterGroup) {
 // 路由前缀: /api/mall/customer
 // 认证: AuthMiddleware(用户Token)
 // 白名单: 通过SpanFilter判断
-// TODO: 完善JWT Token解析逻辑
+// Token解析: customer.ParseToken(用户侧独立密钥,拒绝已禁用用户)
 func (r *Router) customerRoute(root *gin.RouterGroup) {
-	cstRoot := root.Group("/customer", AuthMiddleware(r.SpanFilter, func(ctx context.Context, token string) (*common.User, error) {
-		// TODO: 实现真实的JWT Token解析
-		return &common.User{}, nil
-	}))
+	cstRoot := root.Group("/customer", AuthMiddleware(r.SpanFilter, r.customer.ParseToken))
+	// 滑块验证码(白名单,与管理后台共用同一套验证码服务)
+	cstRoot.GET("/user/verify/captcha", r.admin.GetSmsCodeCaptcha)
+	// 滑块验证码校验(白名单)
+	cstRoot.POST("/user/verify/captcha/check", r.admin.CheckSmsCodeCaptcha)
+	// 手机号+密码登录(白名单)
+	cstRoot.POST("/user/mobile/password_login", r.customer.MobilePasswordLogin)
 	// 用户信息接口
-	cstRoot.Any("/user/info", r.admin.GetUserInfo)
+	cstRoot.GET("/user/info", r.customer.GetUserInfo)
+	// 课程列表与搜索(白名单)
+	cstRoot.GET("/course/list", r.customer.SearchGoods)
+	// 课程详情(白名单)
+	cstRoot.GET("/course/detail", r.customer.GetCourseDetail)
+	// 课程课时列表(白名单)
+	cstRoot.GET("/course/lesson/list", r.customer.ListCourseLessons)
+	// 用户下单
+	cstRoot.POST("/order/create", r.customer.PlaceOrder)
+	// 用户取消订单
+	cstRoot.POST("/order/cancel", r.customer.CancelOrder)
+	// 用户确认收货
+	cstRoot.POST("/order/confirm", r.customer.ConfirmOrder)
 }
 
 // adminRoute 注册管理后台路由
 // 路由前缀: /api/mall/admin
 // 认证: AdminAuthMiddleware(管理员Token)
 // 白名单: 登录、验证码等接口无需认证
-// TODO: 完善JWT Token解析逻辑
+// Token解析: admin.ParseToken(校验签名、过期、签发者和会话,并拒绝已禁用/已删除的管理员)
+// 权限: 通过permRoot注册的路由需声明权限编码,由AdminPermMiddleware校验,超级管理员跳过校验
 func (r *Router) adminRoute(root *gin.RouterGroup) {
-	adminRoot := root.Group("/admin", AdminAuthMiddleware(r.SpanFilter, func(ctx context.Context, token string) (*common.AdminUser, error) {
-		// TODO: 实现真实的JWT Token解析
-		return &common.AdminUser{
-			UserID: 1,
-			Name:   "admin",
-		}, nil
-	}))
+	adminRoot := root.Group("/admin", AdminAuthMiddleware(r.SpanFilter, r.admin.ParseToken))
+	permRoot := &permGroup{group: adminRoot, check: r.admin.CheckPermission, routes: &r.adminPerms}
 
 	// ========== 登录相关(无需认证,在白名单中) ==========
 	// 获取滑块验证码
 	adminRoot.GET("/v1/user/verify/captcha", r.admin.GetSmsCodeCaptcha)
 	// 校验滑块验证码
 	adminRoot.POST("/v1/user/verify/captcha/check", r.admin.CheckSmsCodeCaptcha)
+	// 手机号+密码登录
+	adminRoot.POST("/v1/user/mobile/password_login", r.admin.MobilePasswordLogin)
+	// 下发登录短信验证码(需滑块Ticket)
+	adminRoot.POST("/v1/user/verify/smscode", r.admin.SendSmsCode)
+	// 手机号+短信验证码登录
+	adminRoot.POST("/v1/user/mobile/verify_login", r.admin.MobileVerifyLogin)
+	// 下发重置密码短信验证码(需滑块Ticket)
+	adminRoot.POST("/v1/user/password/reset/smscode", r.admin.SendResetPasswordSmsCode)
+	// 短信验证码重置密码
+	adminRoot.POST("/v1/user/password/reset", r.admin.ResetPassword)
 
-	// ========== 用户管理(需要认证) ==========
+	// ========== 个人相关(需要认证,登录即可访问) ==========
 	// 获取用户信息
 	adminRoot.GET("/v1/user/info", r.admin.GetUserInfo)
+	// 获取当前用户的有效权限和菜单树
+	adminRoot.GET("/v1/user/perm", r.admin.GetMyPermissions)
+	// 修改自己的密码
+	adminRoot.POST("/v1/user/password/change", r.admin.ChangePassword)
+
+	// ========== 用户管理(需要认证和权限) ==========
+	// 分页查询用户列表
+	permRoot.GET("/v1/user/list", "user:list", r.admin.ListUsers)
 	// 创建用户
-	adminRoot.POST("/v1/user/create", r.admin.CreateUser)
+	permRoot.POST("/v1/user/create", "user:create", r.admin.CreateUser)
 	// 更新用户
-	adminRoot.POST("/v1/user/update", r.admin.UpdateUser)
+	permRoot.POST("/v1/user/update", "user:update", r.admin.UpdateUser)
+	// 启用/禁用用户
+	permRoot.POST("/v1/user/status", "user:status", r.admin.UpdateUserStatus)
+	// 删除用户(软删除)
+	permRoot.POST("/v1/user/delete", "user:delete", r.admin.DeleteUser)
+	// 恢复已删除的用户(仅超级管理员)
+	permRoot.POST("/v1/user/restore", "user:restore", r.admin.RestoreUser)
+	// 查询用户分配的角色
+	permRoot.GET("/v1/user/role", "user:role:view", r.admin.GetUserRoles)
+	// 分配用户角色(整体替换)
+	permRoot.POST("/v1/user/role/update", "user:role:update", r.admin.UpdateUserRoles)
+
+	// ========== 角色管理(需要认证和权限) ==========
+	// 分页查询角色列表
+	permRoot.GET("/v1/role/list", "role:list", r.admin.ListRoles)
+	// 创建角色
+	permRoot.POST("/v1/role/create", "role:create", r.admin.CreateRole)
+	// 更新角色
+	permRoot.POST("/v1/role/update", "role:update", r.admin.UpdateRole)
+	// 启用角色
+	permRoot.POST("/v1/role/enable", "role:status", r.admin.EnableRole)
+	// 禁用角色
+	permRoot.POST("/v1/role/disable", "role:status", r.admin.DisableRole)
+	// 删除角色
+	permRoot.POST("/v1/role/delete", "role:delete", r.admin.DeleteRole)
+	// 查询角色权限
+	permRoot.GET("/v1/role/perm", "role:perm:view", r.admin.GetRolePermissions)
+	// 设置角色权限(整体替换)
+	permRoot.POST("/v1/role/perm/update", "role:perm:update", r.admin.UpdateRolePermissions)
+
+	// ========== 权限管理(需要认证和权限) ==========
+	// 获取完整权限树
+	permRoot.GET("/v1/perm/tree", "perm:tree", r.admin.GetPermissionTree)
+	// 创建权限
+	permRoot.POST("/v1/perm/create", "perm:create", r.admin.CreatePermission)
+	// 更新权限(含移动节点)
+	permRoot.POST("/v1/perm/update", "perm:update", r.admin.UpdatePermission)
+	// 启用权限
+	permRoot.POST("/v1/perm/enable", "perm:status", r.admin.EnablePermission)
+	// 禁用权限(连同子孙节点)
+	permRoot.POST("/v1/perm/disable", "perm:status", r.admin.DisablePermission)
+	// 删除权限
+	permRoot.POST("/v1/perm/delete", "perm:delete", r.admin.DeletePermission)
+
+	// ========== 课程商品管理(需要认证和权限) ==========
+	// 分页查询课程商品
+	permRoot.GET("/v1/goods/list", "goods:list", r.admin.ListGoods)
+	// 获取课程商品详情
+	permRoot.GET("/v1/goods/detail", "goods:detail", r.admin.GetGoods)
+	// 创建课程商品
+	permRoot.POST("/v1/goods/create", "goods:create", r.admin.CreateGoods)
+	// 编辑课程商品
+	permRoot.POST("/v1/goods/update", "goods:update", r.admin.UpdateGoods)
+	// 上架课程商品
+	permRoot.POST("/v1/goods/publish", "goods:status", r.admin.PublishGoods)
+	// 下架课程商品
+	permRoot.POST("/v1/goods/unpublish", "goods:status", r.admin.UnpublishGoods)
+	// 查询课程商品价格历史
+	permRoot.GET("/v1/goods/price/list", "goods:price:list", r.admin.ListGoodsPrices)
+	// 排期未来价格
+	permRoot.POST("/v1/goods/price/schedule", "goods:price:update", r.admin.ScheduleGoodsPrice)
+	// 取消尚未生效的价格排期
+	permRoot.POST("/v1/goods/price/cancel", "goods:price:update", r.admin.CancelGoodsPrice)
+
+	// ========== 课程目录管理(需要认证和权限) ==========
+	// 获取课程目录树
+	permRoot.GET("/v1/catalog/tree", "catalog:tree", r.admin.GetCatalogTree)
+	// 新增目录节点
+	permRoot.POST("/v1/catalog/create", "catalog:create", r.admin.CreateCatalog)
+	// 重命名目录节点
+	permRoot.POST("/v1/catalog/rename", "catalog:update", r.admin.RenameCatalog)
+	// 删除目录节点
+	permRoot.POST("/v1/catalog/delete", "catalog:delete", r.admin.DeleteCatalog)
+	// 批量移动/排序目录节点
+	permRoot.POST("/v1/catalog/move", "catalog:update", r.admin.MoveCatalogs)
+
+	// ========== 课程课时管理(需要认证和权限) ==========
+	// 获取课程课时列表
+	permRoot.GET("/v1/lesson/list", "lesson:list", r.admin.ListLessons)
+	// 挂载课时
+	permRoot.POST("/v1/lesson/attach", "lesson:attach", r.admin.AttachLesson)
+	// 移除课时
+	permRoot.POST("/v1/lesson/detach", "lesson:detach", r.admin.DetachLesson)
+	// 批量调整课时目录和排序
+	permRoot.POST("/v1/lesson/sort", "lesson:update", r.admin.SortLessons)
+	// 开启课时试听
+	permRoot.POST("/v1/lesson/trial/enable", "lesson:trial", r.admin.EnableLessonTrial)
+	// 关闭课时试听
+	permRoot.POST("/v1/lesson/trial/disable", "lesson:trial", r.admin.DisableLessonTrial)
+	// 设置课时可见时间
+	permRoot.POST("/v1/lesson/schedule", "lesson:update", r.admin.ScheduleLesson)
+
+	// ========== 短信模板管理(需要认证和权限) ==========
+	// 查询短信模板列表
+	permRoot.GET("/v1/sms/template/list", "sms:template:list", r.admin.ListSmsTemplates)
+	// 创建短信模板
+	permRoot.POST("/v1/sms/template/create", "sms:template:create", r.admin.CreateSmsTemplate)
+	// 更新短信模板
+	permRoot.POST("/v1/sms/template/update", "sms:template:update", r.admin.UpdateSmsTemplate)
+	// 启用短信模板
+	permRoot.POST("/v1/sms/template/enable", "sms:template:status", r.admin.EnableSmsTemplate)
+	// 禁用短信模板
+	permRoot.POST("/v1/sms/template/disable", "sms:template:status", r.admin.DisableSmsTemplate)
+	// 发送测试短信
+	permRoot.POST("/v1/sms/template/send_test", "sms:template:send_test", r.admin.SendTestSms)
 }
@@ -0,0 +1,18 @@
+// Package router 路由层-后台任务
+// 职责: 启动随HTTP服务一起运行的后台任务
+// 多实例: 每个实例都会启动全部任务,任务自身保证多实例同时运行时不会重复处理
+package router
+
+import (
+	"context"
+)
+
+// RunTasks 启动后台任务
+// 参数: ctx 上下文,取消后全部任务退出
+// 任务:
+//   - 未支付订单自动取消
+//
+// 调用链: main.main -> RunTasks -> customer.RunOrderTimeout
+func (r *Router) RunTasks(ctx context.Context) {
+	go r.customer.RunOrderTimeout(ctx)
+}
@@ -0,0 +1,314 @@
+// Package admin 管理员业务逻辑层-课程目录管理
+// 职责: 课程目录树的查询、新增、重命名、删除和拖拽移动
+// 规则:
+//   - 目录通过GoodID归属课程商品,父级必须属于同一课程商品
+//   - 顶级目录ParentID=-1、Level=1,子目录Level=父级Level+1,由服务端计算
+//   - 移动后子孙节点的Level随之重新计算,全部变化在同一事务中提交
+//   - 目录下有子目录或课时时不能删除
+package admin
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"mall/adaptor/repo/course"
+	"mall/adaptor/repo/model"
+	"mall/common"
+	"mall/consts"
+	"mall/service/do"
+	"mall/service/dto"
+	"mall/utils/logger"
+)
+
+// GetCatalogTree 获取课程商品的目录树
+// 参数:
+//   - ctx: 上下文
+//   - req: 目录树请求DTO
+//
+// 返回: 顶级目录节点列表(子节点嵌套在Children中)和错误码
+// 调用链: api.GetCatalogTree -> service.GetCatalogTree -> repo.ListCatalogs
+func (s *Service) GetCatalogTree(ctx context.Context, req *dto.CatalogTreeReq) ([]*dto.CatalogNode, common.Errno) {
+	if _, errno := s.getGoods(ctx, req.GoodsID); !errno.IsOk() {
+		return nil, errno
+	}
+	catalogs, err := s.catalog.ListCatalogs(ctx, req.GoodsID)
+	if err != nil {
+		logger.Error("GetCatalogTree ListCatalogs error", zap.Error(err), zap.Int64("goods_id", req.GoodsID))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	return dto.NewCatalogTree(catalogs), common.OK
+}
+
+// CreateCatalog 新增目录节点
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - req: 新增目录请求DTO
+//
+// 返回: 目录ID和错误码
+// 业务流程:
+//  1. 校验名称和课程商品
+//  2. 在事务中加锁读取课程商品的全部目录,校验父级属于同一课程商品,根据父级计算层级后写入
+//
+// 调用链: api.CreateCatalog -> service.CreateCatalog -> repo.CreateCatalog
+func (s *Service) CreateCatalog(ctx context.Context, adminUser *common.AdminUser, req *dto.CreateCatalogReq) (int64, common.Errno) {
+	// 1. 参数校验
+	if req.Name == "" {
+		return 0, common.ParamErr.WithMsg("目录名称不能为空")
+	}
+	if _, errno := s.getGoods(ctx, req.GoodsID); !errno.IsOk() {
+		return 0, errno
+	}
+
+	// 2. 加锁校验父级,计算层级后写入
+	id, err := s.catalog.CreateCatalog(ctx, &do.CreateCatalog{
+		AdminUserID: adminUser.UserID, // 记录操作人ID
+		GoodsID:     req.GoodsID,
+		ParentID:    normalizeCatalogParentID(req.ParentID),
+		Name:        req.Name,
+		Sort:        req.Sort,
+	})
+	if err != nil {
+		if errors.Is(err, course.ErrCatalogParent) {
+			return 0, common.CatalogParentErr
+		}
+		logger.Error("CreateCatalog error", zap.Error(err), zap.Any("req", req))
+		return 0, common.DatabaseErr.WithErr(err)
+	}
+	return id, common.OK
+}
+
+// RenameCatalog 重命名目录节点
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - req: 重命名请求DTO
+//
+// 返回: 错误码
+// 调用链: api.RenameCatalog -> service.RenameCatalog -> repo.RenameCatalog
+func (s *Service) RenameCatalog(ctx context.Context, adminUser *common.AdminUser, req *dto.RenameCatalogReq) common.Errno {
+	if req.Name == "" {
+		return common.ParamErr.WithMsg("目录名称不能为空")
+	}
+	if _, errno := s.getCatalog(ctx, req.ID); !errno.IsOk() {
+		return errno
+	}
+
+	err := s.catalog.RenameCatalog(ctx, &do.RenameCatalog{
+		AdminUserID: adminUser.UserID, // 记录操作人ID
+		ID:          req.ID,
+		Name:        req.Name,
+	})
+	if err != nil {
+		logger.Error("RenameCatalog error", zap.Error(err), zap.Any("req", req))
+		return common.DatabaseErr.WithErr(err)
+	}
+	return common.OK
+}
+
+// DeleteCatalog 删除目录节点
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - req: 目录ID请求DTO
+//
+// 返回: 错误码
+// 限制: 目录下有子目录或课时时返回CatalogInUseErr,在事务中加锁读取课程商品的全部目录后校验并删除
+// 调用链: api.DeleteCatalog -> service.DeleteCatalog -> repo.DeleteCatalog
+func (s *Service) DeleteCatalog(ctx context.Context, adminUser *common.AdminUser, req *dto.CatalogIDReq) common.Errno {
+	catalog, errno := s.getCatalog(ctx, req.ID)
+	if !errno.IsOk() {
+		return errno
+	}
+	err := s.catalog.DeleteCatalog(ctx, &do.DeleteCatalog{
+		GoodsID: catalog.GoodID,
+		ID:      req.ID,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return common.DataNotFoundErr
+		}
+		if errors.Is(err, course.ErrCatalogInUse) {
+			return common.CatalogInUseErr
+		}
+		logger.Error("DeleteCatalog error", zap.Error(err), zap.Int64("id", req.ID))
+		return common.DatabaseErr.WithErr(err)
+	}
+	logger.Info("DeleteCatalog", zap.Int64("id", req.ID), zap.Int64("admin_user_id", adminUser.UserID))
+	return common.OK
+}
+
+// MoveCatalogs 批量移动/排序目录节点
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - req: 移动请求DTO,包含拖拽后每个节点的新父级和排序
+//
+// 返回: 错误码
+// 业务流程:
+//  1. 校验课程商品存在
+//  2. 在事务中加锁读取课程商品的全部目录,由planCatalogMove校验并计算位置有变化的节点
+//  3. 位置有变化的节点在同一事务中更新
+//
+// 调用链: api.MoveCatalogs -> service.MoveCatalogs -> repo.MoveCatalogs
+func (s *Service) MoveCatalogs(ctx context.Context, adminUser *common.AdminUser, req *dto.MoveCatalogReq) common.Errno {
+	if len(req.Items) == 0 {
+		return common.ParamErr.WithMsg("移动的目录不能为空")
+	}
+	if _, errno := s.getGoods(ctx, req.GoodsID); !errno.IsOk() {
+		return errno
+	}
+
+	updated, err := s.catalog.MoveCatalogs(ctx, &do.MoveCatalogs{
+		AdminUserID: adminUser.UserID, // 记录操作人ID
+		GoodsID:     req.GoodsID,
+		Plan: func(catalogs []*model.CourseCatalog) ([]*do.CatalogPosition, error) {
+			positions, errno := planCatalogMove(catalogs, req.Items)
+			if !errno.IsOk() {
+				return nil, errno
+			}
+			return positions, nil
+		},
+	})
+	if err != nil {
+		var errno common.Errno
+		if errors.As(err, &errno) {
+			return errno
+		}
+		logger.Error("MoveCatalogs error", zap.Error(err), zap.Any("req", req))
+		return common.DatabaseErr.WithErr(err)
+	}
+	logger.Info("MoveCatalogs", zap.Int64("goods_id", req.GoodsID), zap.Int("updated", updated), zap.Int64("admin_user_id", adminUser.UserID))
+	return common.OK
+}
+
+// planCatalogMove 在当前目录上应用本次移动,计算位置有变化的节点
+// 参数:
+//   - catalogs: 课程商品的全部目录(事务内加锁读取)
+//   - items: 本次提交的移动
+//
+// 返回: 位置有变化的节点和错误码
+// 业务流程:
+//  1. 在内存中应用本次移动
+//  2. 校验节点和父级都属于该课程商品,且不会形成环
+//  3. 从顶级节点开始重新计算层级(含未提交但受影响的子孙节点)
+//  4. 找出父级、层级或排序有变化的节点
+func planCatalogMove(catalogs []*model.CourseCatalog, items []*dto.MoveCatalogItem) ([]*do.CatalogPosition, common.Errno) {
+	// 1. 应用移动
+	moved := make(map[int64]*do.CatalogPosition, len(catalogs))
+	for _, catalog := range catalogs {
+		moved[catalog.ID] = &do.CatalogPosition{
+			ID:       catalog.ID,
+			ParentID: catalog.ParentID,
+			Level:    catalog.Level,
+			Sort:     catalog.Sort,
+		}
+	}
+
+	// 2. 校验节点和父级
+	seen := make(map[int64]bool, len(items))
+	for _, item := range items {
+		pos, ok := moved[item.ID]
+		if !ok {
+			return nil, common.DataNotFoundErr.WithMsg("目录不存在或不属于该课程")
+		}
+		if seen[item.ID] {
+			return nil, common.ParamErr.WithMsg("目录重复提交")
+		}
+		seen[item.ID] = true
+		parentID := normalizeCatalogParentID(item.ParentID)
+		if _, ok = moved[parentID]; !ok && parentID != consts.CatalogRootParentID {
+			return nil, common.CatalogParentErr
+		}
+		pos.ParentID = parentID
+		pos.Sort = item.Sort
+	}
+	for _, item := range items {
+		if catalogInCycle(moved, item.ID) {
+			return nil, common.CatalogCycleErr
+		}
+	}
+
+	// 3. 重新计算层级
+	calcCatalogLevels(moved)
+
+	// 4. 找出位置有变化的节点
+	positions := make([]*do.CatalogPosition, 0)
+	for _, catalog := range catalogs {
+		pos := moved[catalog.ID]
+		if pos.ParentID != catalog.ParentID || pos.Level != catalog.Level || pos.Sort != catalog.Sort {
+			positions = append(positions, pos)
+		}
+	}
+	return positions, common.OK
+}
+
+// getCatalog 根据ID获取目录节点
+// 返回: 目录节点和错误码,不存在返回DataNotFoundErr
+func (s *Service) getCatalog(ctx context.Context, id int64) (*model.CourseCatalog, common.Errno) {
+	catalog, err := s.catalog.GetCatalog(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.DataNotFoundErr
+		}
+		logger.Error("getCatalog GetCatalog error", zap.Error(err), zap.Int64("id", id))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	return catalog, common.OK
+}
+
+// normalizeCatalogParentID 统一顶级目录的父级ID
+// 规则: 不传、0或负数均视为顶级(-1)
+func normalizeCatalogParentID(parentID int64) int64 {
+	if parentID <= 0 {
+		return consts.CatalogRootParentID
+	}
+	return parentID
+}
+
+// catalogInCycle 判断节点沿父级向上是否会回到自身
+// 参数:
+//   - positions: 应用移动后的全部节点位置
+//   - id: 节点ID
+//
+// 返回: 形成环返回true
+func catalogInCycle(positions map[int64]*do.CatalogPosition, id int64) bool {
+	visited := make(map[int64]bool)
+	for cur := positions[id].ParentID; cur != consts.CatalogRootParentID; {
+		if cur == id || visited[cur] { // 历史数据已存在环,同样拒绝
+			return true
+		}
+		visited[cur] = true
+		pos, ok := positions[cur]
+		if !ok {
+			return false
+		}
+		cur = pos.ParentID
+	}
+	return false
+}
+
+// calcCatalogLevels 根据父子关系重新计算全部节点的层级
+// 参数: positions 应用移动后的全部节点位置,Level会被原地修改
+// 规则: 顶级节点(或父级不在本课程下的节点)为1,子节点为父级+1
+func calcCatalogLevels(positions map[int64]*do.CatalogPosition) {
+	children := make(map[int64][]*do.CatalogPosition, len(positions))
+	queue := make([]*do.CatalogPosition, 0)
+	for _, pos := range positions {
+		if _, ok := positions[pos.ParentID]; !ok {
+			pos.Level = consts.CatalogRootLevel
+			queue = append(queue, pos)
+			continue
+		}
+		children[pos.ParentID] = append(children[pos.ParentID], pos)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, child := range children[cur.ID] {
+			child.Level = cur.Level + 1
+			queue = append(queue, child)
+		}
+	}
+}
@@ -0,0 +1,315 @@
+// Package admin 管理员业务逻辑层-课程商品管理
+// 职责: 课程商品的创建、编辑、列表、详情和上下架
+// 规则:
+//   - 免费商品(SaleType=1)价格必须为0,收费商品价格必须大于0
+//   - 服务时长只能是1/2/3/4(一个月/三个月/半年/一年)
+//   - 新建商品默认下架,上架前必须有封面图、目录和至少一个课时
+//   - 上架中的商品编辑后仍需满足上架条件,每次上下架写入一条上下架记录
+//   - 下架只影响售卖,已购用户的权益(user_course_goods)保持不变
+//   - 创建和改价都会记录价格历史,未来价格通过价格管理接口排期
+package admin
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"mall/adaptor/repo/model"
+	"mall/common"
+	"mall/consts"
+	"mall/service/do"
+	"mall/service/dto"
+	"mall/utils/logger"
+)
+
+// ListGoods 分页查询课程商品
+// 参数:
+//   - ctx: 上下文
+//   - req: 查询条件DTO(含分页参数)
+//
+// 返回: 分页响应和错误码
+// 调用链: api.ListGoods -> service.ListGoods -> repo.ListGoods
+func (s *Service) ListGoods(ctx context.Context, req *dto.ListGoodsReq) (*dto.PageResp[*dto.GoodsItem], common.Errno) {
+	req.Normalize()
+	goods, total, err := s.goods.ListGoods(ctx, &do.ListGoods{
+		Offset:      req.Offset(),
+		Limit:       req.PageSize,
+		Name:        req.Name,
+		SaleType:    req.SaleType,
+		ServiceTime: req.ServiceTime,
+		Status:      req.Status,
+	})
+	if err != nil {
+		logger.Error("ListGoods error", zap.Error(err), zap.Any("req", req))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	items := make([]*dto.GoodsItem, 0, len(goods))
+	for _, g := range goods {
+		items = append(items, &dto.GoodsItem{
+			ID:          g.ID,
+			Name:        g.Name,
+			CoverKey:    g.CoverKey,
+			CoursePrice: g.CoursePrice,
+			ServiceTime: g.ServiceTime,
+			SaleType:    g.SaleType,
+			Status:      g.Status,
+			CreateBy:    g.CreateBy,
+			UpdateBy:    g.UpdateBy,
+			CreateAt:    g.CreateAt.UnixMilli(),
+			UpdateAt:    g.UpdateAt.UnixMilli(),
+		})
+	}
+	return dto.NewPageResp(&req.PageReq, items, total), common.OK
+}
+
+// GetGoods 获取课程商品详情
+// 参数:
+//   - ctx: 上下文
+//   - req: 商品ID请求DTO
+//
+// 返回: 商品详情和错误码
+// 调用链: api.GetGoods -> service.GetGoods -> repo.GetGoods
+func (s *Service) GetGoods(ctx context.Context, req *dto.GoodsIDReq) (*dto.GoodsDetail, common.Errno) {
+	g, errno := s.getGoods(ctx, req.ID)
+	if !errno.IsOk() {
+		return nil, errno
+	}
+	return &dto.GoodsDetail{
+		ID:             g.ID,
+		Name:           g.Name,
+		CoverKey:       g.CoverKey,
+		DetailCoverKey: g.DetailCoverKey,
+		Detail:         g.Detail,
+		CoursePrice:    g.CoursePrice,
+		ServiceTime:    g.ServiceTime,
+		SaleType:       g.SaleType,
+		Status:         g.Status,
+		Features:       g.Features,
+		CreateBy:       g.CreateBy,
+		UpdateBy:       g.UpdateBy,
+		CreateAt:       g.CreateAt.UnixMilli(),
+		UpdateAt:       g.UpdateAt.UnixMilli(),
+	}, common.OK
+}
+
+// CreateGoods 创建课程商品
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - req: 创建商品请求DTO
+//
+// 返回: 商品ID和错误码
+// 业务流程:
+//  1. 校验名称、售卖类型、价格和服务时长
+//  2. 写入数据库,默认下架,记录创建人和更新人
+//
+// 调用链: api.CreateGoods -> service.CreateGoods -> repo.CreateGoods
+func (s *Service) CreateGoods(ctx context.Context, adminUser *common.AdminUser, req *dto.CreateGoodsReq) (int64, common.Errno) {
+	// 1. 参数校验
+	if errno := checkGoodsParam(req.Name, req.SaleType, req.CoursePrice, req.ServiceTime); !errno.IsOk() {
+		return 0, errno
+	}
+
+	// 2. 写入数据库
+	id, err := s.goods.CreateGoods(ctx, &do.CreateGoods{
+		AdminUserID:    adminUser.UserID, // 记录创建人ID
+		Name:           req.Name,
+		CoverKey:       req.CoverKey,
+		DetailCoverKey: req.DetailCoverKey,
+		Detail:         req.Detail,
+		CoursePrice:    req.CoursePrice,
+		ServiceTime:    req.ServiceTime,
+		SaleType:       req.SaleType,
+		Features:       req.Features,
+	})
+	if err != nil {
+		logger.Error("CreateGoods error", zap.Error(err), zap.Any("req", req))
+		return 0, common.DatabaseErr.WithErr(err)
+	}
+	return id, common.OK
+}
+
+// UpdateGoods 编辑课程商品
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - req: 更新商品请求DTO
+//
+// 返回: 错误码
+// 可更新字段: 上下架状态以外的全部字段,上下架由单独接口处理
+// 上架中: 编辑后的商品仍需满足上架条件(如不能清空封面图)
+// 价格历史: 价格有变化时记录一条立即生效的价格
+// 调用链: api.UpdateGoods -> service.UpdateGoods -> repo.UpdateGoods
+func (s *Service) UpdateGoods(ctx context.Context, adminUser *common.AdminUser, req *dto.UpdateGoodsReq) common.Errno {
+	if errno := checkGoodsParam(req.Name, req.SaleType, req.CoursePrice, req.ServiceTime); !errno.IsOk() {
+		return errno
+	}
+	g, errno := s.getGoods(ctx, req.ID)
+	if !errno.IsOk() {
+		return errno
+	}
+	if g.Status == consts.GoodsOnShelf {
+		edited := *g
+		edited.CoverKey = req.CoverKey
+		if errno = s.checkGoodsPublishable(ctx, &edited); !errno.IsOk() {
+			return errno
+		}
+	}
+
+	err := s.goods.UpdateGoods(ctx, &do.UpdateGoods{
+		AdminUserID:    adminUser.UserID, // 记录更新人ID
+		ID:             req.ID,
+		Name:           req.Name,
+		CoverKey:       req.CoverKey,
+		DetailCoverKey: req.DetailCoverKey,
+		Detail:         req.Detail,
+		CoursePrice:    req.CoursePrice,
+		ServiceTime:    req.ServiceTime,
+		SaleType:       req.SaleType,
+		Features:       req.Features,
+		PriceChanged:   g.CoursePrice != req.CoursePrice,
+	})
+	if err != nil {
+		logger.Error("UpdateGoods error", zap.Error(err), zap.Any("req", req))
+		return common.DatabaseErr.WithErr(err)
+	}
+	return common.OK
+}
+
+// UpdateGoodsStatus 上架或下架课程商品
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - id: 商品ID
+//   - status: consts.GoodsOnShelf上架 / consts.GoodsOffShelf下架
+//
+// 返回: 错误码
+// 业务流程:
+//  1. 校验商品存在,状态未变化时直接返回
+//  2. 在事务中锁定商品行,上架时在锁内校验封面图、目录和课时,更新状态并写入上下架记录(操作人、操作时间)
+//
+// 注意: 下架不回收已购用户的权益,user_course_goods保持不变
+// 调用链: api.PublishGoods/UnpublishGoods -> service.UpdateGoodsStatus -> repo.UpdateGoodsStatus
+func (s *Service) UpdateGoodsStatus(ctx context.Context, adminUser *common.AdminUser, id int64, status int32) common.Errno {
+	// 1. 校验商品存在
+	g, errno := s.getGoods(ctx, id)
+	if !errno.IsOk() {
+		return errno
+	}
+	if g.Status == status {
+		return common.OK
+	}
+
+	// 2. 更新状态,上架时在事务内校验上架条件
+	req := &do.UpdateGoodsStatus{
+		AdminUserID: adminUser.UserID, // 记录操作人ID
+		ID:          id,
+		FromStatus:  g.Status,
+		Status:      status,
+	}
+	if status == consts.GoodsOnShelf {
+		req.Check = func(locked *model.CourseGood, catalogs, lessons int64) error {
+			if errno := goodsPublishable(locked, catalogs, lessons); !errno.IsOk() {
+				return errno
+			}
+			return nil
+		}
+	}
+	if err := s.goods.UpdateGoodsStatus(ctx, req); err != nil {
+		var errno common.Errno
+		if errors.As(err, &errno) {
+			return errno
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return common.DataNotFoundErr
+		}
+		logger.Error("UpdateGoodsStatus error", zap.Error(err), zap.Int64("id", id))
+		return common.DatabaseErr.WithErr(err)
+	}
+	logger.Info("UpdateGoodsStatus", zap.Int64("id", id), zap.Int32("status", status), zap.Int64("admin_user_id", adminUser.UserID))
+	return common.OK
+}
+
+// checkGoodsPublishable 校验课程商品是否满足上架条件
+// 参数:
+//   - ctx: 上下文
+//   - g: 课程商品
+//
+// 返回: 错误码,缺少封面图、目录或课时返回GoodsPublishErr
+// 调用链: service.UpdateGoods -> checkGoodsPublishable -> goodsPublishable
+func (s *Service) checkGoodsPublishable(ctx context.Context, g *model.CourseGood) common.Errno {
+	catalogs, err := s.catalog.CountCatalogs(ctx, g.ID)
+	if err != nil {
+		logger.Error("checkGoodsPublishable CountCatalogs error", zap.Error(err), zap.Int64("id", g.ID))
+		return common.DatabaseErr.WithErr(err)
+	}
+	lessons, err := s.lesson.CountLessons(ctx, g.ID)
+	if err != nil {
+		logger.Error("checkGoodsPublishable CountLessons error", zap.Error(err), zap.Int64("id", g.ID))
+		return common.DatabaseErr.WithErr(err)
+	}
+	return goodsPublishable(g, catalogs, lessons)
+}
+
+// goodsPublishable 根据商品及其目录、课时数量判断是否满足上架条件
+// 参数:
+//   - g: 课程商品
+//   - catalogs: 目录节点数量
+//   - lessons: 课时数量
+//
+// 返回: 错误码,缺少封面图、目录或课时返回GoodsPublishErr
+func goodsPublishable(g *model.CourseGood, catalogs, lessons int64) common.Errno {
+	if g.CoverKey == "" {
+		return common.GoodsPublishErr.WithMsg("缺少封面图")
+	}
+	if catalogs == 0 {
+		return common.GoodsPublishErr.WithMsg("缺少课程目录")
+	}
+	if lessons == 0 {
+		return common.GoodsPublishErr.WithMsg("至少需要一个课时")
+	}
+	return common.OK
+}
+
+// getGoods 根据ID获取课程商品
+// 返回: 商品对象和错误码,不存在返回DataNotFoundErr
+func (s *Service) getGoods(ctx context.Context, id int64) (*model.CourseGood, common.Errno) {
+	g, err := s.goods.GetGoods(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.DataNotFoundErr
+		}
+		logger.Error("getGoods GetGoods error", zap.Error(err), zap.Int64("id", id))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	return g, common.OK
+}
+
+// checkGoodsParam 校验课程商品参数
+// 规则:
+//   - 名称必填
+//   - 免费商品价格必须为0,收费商品价格必须大于0
+//   - 服务时长只能是1/2/3/4
+func checkGoodsParam(name string, saleType int32, price int64, serviceTime int32) common.Errno {
+	if name == "" {
+		return common.ParamErr.WithMsg("商品名称不能为空")
+	}
+	switch saleType {
+	case consts.SaleTypeFree:
+		if price != 0 {
+			return common.ParamErr.WithMsg("免费商品价格必须为0")
+		}
+	case consts.SaleTypePaid:
+		if price <= 0 {
+			return common.ParamErr.WithMsg("收费商品价格必须大于0")
+		}
+	default:
+		return common.ParamErr.WithMsg("售卖类型只能是免费或收费")
+	}
+	switch serviceTime {
+	case consts.ServiceTimeOneMonth, consts.ServiceTimeThreeMonths, consts.ServiceTimeHalfYear, consts.ServiceTimeOneYear:
+	default:
+		return common.ParamErr.WithMsg("服务时长只能是一个月、三个月、半年或一年")
+	}
+	return common.OK
+}
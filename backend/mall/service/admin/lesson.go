@@ -0,0 +1,324 @@
+// Package admin 管理员业务逻辑层-课程课时管理
+// 职责: 课时的挂载、移除、排序、试听标记和定时可见
+// 规则:
+//   - 课时挂在课程商品的目录节点下,目录必须属于同一课程商品
+//   - 同一录播课时在一个课程商品中只能挂载一次
+//   - ShowTime之前用户端不可见,不传表示立即可见
+//   - 上架中的课程商品至少保留一个课时
+package admin
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"mall/adaptor/repo/course"
+	"mall/adaptor/repo/model"
+	"mall/common"
+	"mall/consts"
+	"mall/service/do"
+	"mall/service/dto"
+	"mall/utils/logger"
+	"time"
+)
+
+// ListLessons 获取课程商品的全部课时
+// 参数:
+//   - ctx: 上下文
+//   - req: 课时列表请求DTO
+//
+// 返回: 课时列表(含未到可见时间的课时)和错误码
+// 调用链: api.ListLessons -> service.ListLessons -> repo.ListLessons
+func (s *Service) ListLessons(ctx context.Context, req *dto.ListLessonReq) ([]*dto.LessonItem, common.Errno) {
+	if _, errno := s.getGoods(ctx, req.GoodsID); !errno.IsOk() {
+		return nil, errno
+	}
+	lessons, err := s.lesson.ListLessons(ctx, req.GoodsID)
+	if err != nil {
+		logger.Error("ListLessons error", zap.Error(err), zap.Int64("goods_id", req.GoodsID))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	items := make([]*dto.LessonItem, 0, len(lessons))
+	for _, lesson := range lessons {
+		items = append(items, &dto.LessonItem{
+			ID:          lesson.ID,
+			CatalogID:   lesson.CatalogID,
+			LessonID:    lesson.LessonID,
+			EnableTrial: lesson.EnableTrial,
+			Sort:        lesson.Sort,
+			ShowTime:    lesson.ShowTime.UnixMilli(),
+			UpdateBy:    lesson.UpdateBy,
+			UpdateAt:    lesson.UpdateAt.UnixMilli(),
+		})
+	}
+	return items, common.OK
+}
+
+// AttachLesson 将录播课时挂载到课程目录下
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - req: 挂载课时请求DTO
+//
+// 返回: 课时记录ID和错误码
+// 业务流程:
+//  1. 校验课程商品存在,目录属于该课程商品
+//  2. 校验录播课时未重复挂载
+//  3. 写入数据库,ShowTime不传时立即可见
+//
+// 调用链: api.AttachLesson -> service.AttachLesson -> repo.CreateLesson
+func (s *Service) AttachLesson(ctx context.Context, adminUser *common.AdminUser, req *dto.AttachLessonReq) (int64, common.Errno) {
+	// 1. 参数校验
+	if req.LessonID <= 0 {
+		return 0, common.ParamErr.WithMsg("录播课时ID不能为空")
+	}
+	if _, errno := s.getGoods(ctx, req.GoodsID); !errno.IsOk() {
+		return 0, errno
+	}
+	if errno := s.checkLessonCatalog(ctx, req.GoodsID, req.CatalogID); !errno.IsOk() {
+		return 0, errno
+	}
+
+	// 2. 重复挂载校验
+	count, err := s.lesson.CountLessonsByLessonID(ctx, req.GoodsID, req.LessonID)
+	if err != nil {
+		logger.Error("AttachLesson CountLessonsByLessonID error", zap.Error(err), zap.Any("req", req))
+		return 0, common.DatabaseErr.WithErr(err)
+	}
+	if count > 0 {
+		return 0, common.LessonExistErr
+	}
+
+	// 3. 写入数据库
+	showTime := time.Now()
+	if req.ShowTime > 0 {
+		showTime = time.UnixMilli(req.ShowTime)
+	}
+	id, err := s.lesson.CreateLesson(ctx, &do.CreateLesson{
+		AdminUserID: adminUser.UserID, // 记录操作人ID
+		GoodsID:     req.GoodsID,
+		CatalogID:   req.CatalogID,
+		LessonID:    req.LessonID,
+		EnableTrial: normalizeLessonTrial(req.EnableTrial),
+		Sort:        req.Sort,
+		ShowTime:    showTime,
+	})
+	if err != nil {
+		logger.Error("AttachLesson CreateLesson error", zap.Error(err), zap.Any("req", req))
+		return 0, common.DatabaseErr.WithErr(err)
+	}
+	return id, common.OK
+}
+
+// DetachLesson 从课程中移除课时
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - req: 课时ID请求DTO
+//
+// 返回: 错误码
+// 限制: 上架中的课程商品不能移除最后一个课时,在事务中锁定商品行后校验,与上架互斥
+// 调用链: api.DetachLesson -> service.DetachLesson -> repo.DeleteLesson
+func (s *Service) DetachLesson(ctx context.Context, adminUser *common.AdminUser, req *dto.LessonIDReq) common.Errno {
+	lesson, errno := s.getLesson(ctx, req.ID)
+	if !errno.IsOk() {
+		return errno
+	}
+	err := s.lesson.DeleteLesson(ctx, &do.DeleteLesson{
+		GoodsID: lesson.CourseGoodsID,
+		ID:      req.ID,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return common.DataNotFoundErr
+		}
+		if errors.Is(err, course.ErrLastLesson) {
+			return common.ParamErr.WithMsg("上架中的课程至少需要保留一个课时")
+		}
+		logger.Error("DetachLesson DeleteLesson error", zap.Error(err), zap.Int64("id", req.ID))
+		return common.DatabaseErr.WithErr(err)
+	}
+	logger.Info("DetachLesson", zap.Int64("id", req.ID), zap.Int64("lesson_id", lesson.LessonID), zap.Int64("admin_user_id", adminUser.UserID))
+	return common.OK
+}
+
+// SortLessons 批量调整课时的目录和排序
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - req: 排序请求DTO,包含拖拽后每个课时的目录和排序
+//
+// 返回: 错误码
+// 业务流程:
+//  1. 读取课程商品的全部课时和目录
+//  2. 校验课时和目标目录都属于该课程商品,CatalogID不传表示不换目录
+//  3. 有变化的课时在同一事务中更新
+//
+// 调用链: api.SortLessons -> service.SortLessons -> repo.SortLessons
+func (s *Service) SortLessons(ctx context.Context, adminUser *common.AdminUser, req *dto.SortLessonReq) common.Errno {
+	if len(req.Items) == 0 {
+		return common.ParamErr.WithMsg("排序的课时不能为空")
+	}
+	if _, errno := s.getGoods(ctx, req.GoodsID); !errno.IsOk() {
+		return errno
+	}
+
+	// 1. 读取全部课时和目录
+	lessons, err := s.lesson.ListLessons(ctx, req.GoodsID)
+	if err != nil {
+		logger.Error("SortLessons ListLessons error", zap.Error(err), zap.Int64("goods_id", req.GoodsID))
+		return common.DatabaseErr.WithErr(err)
+	}
+	catalogs, err := s.catalog.ListCatalogs(ctx, req.GoodsID)
+	if err != nil {
+		logger.Error("SortLessons ListCatalogs error", zap.Error(err), zap.Int64("goods_id", req.GoodsID))
+		return common.DatabaseErr.WithErr(err)
+	}
+	lessonMap := make(map[int64]*model.CourseLesson, len(lessons))
+	for _, lesson := range lessons {
+		lessonMap[lesson.ID] = lesson
+	}
+	catalogIDs := make(map[int64]bool, len(catalogs))
+	for _, catalog := range catalogs {
+		catalogIDs[catalog.ID] = true
+	}
+
+	// 2. 校验课时和目录
+	positions := make([]*do.LessonPosition, 0, len(req.Items))
+	seen := make(map[int64]bool, len(req.Items))
+	for _, item := range req.Items {
+		lesson, ok := lessonMap[item.ID]
+		if !ok {
+			return common.DataNotFoundErr.WithMsg("课时不存在或不属于该课程")
+		}
+		if seen[item.ID] {
+			return common.ParamErr.WithMsg("课时重复提交")
+		}
+		seen[item.ID] = true
+		catalogID := lesson.CatalogID
+		if item.CatalogID > 0 {
+			if !catalogIDs[item.CatalogID] {
+				return common.DataNotFoundErr.WithMsg("目录不存在或不属于该课程")
+			}
+			catalogID = item.CatalogID
+		}
+		if catalogID == lesson.CatalogID && item.Sort == lesson.Sort {
+			continue
+		}
+		positions = append(positions, &do.LessonPosition{
+			ID:        item.ID,
+			CatalogID: catalogID,
+			Sort:      item.Sort,
+		})
+	}
+
+	// 3. 更新有变化的课时
+	if len(positions) == 0 {
+		return common.OK
+	}
+	err = s.lesson.SortLessons(ctx, &do.SortLessons{
+		AdminUserID: adminUser.UserID, // 记录操作人ID
+		GoodsID:     req.GoodsID,
+		Positions:   positions,
+	})
+	if err != nil {
+		logger.Error("SortLessons error", zap.Error(err), zap.Any("req", req))
+		return common.DatabaseErr.WithErr(err)
+	}
+	return common.OK
+}
+
+// UpdateLessonTrial 开启或关闭课时试听
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - id: 课时记录ID
+//   - enableTrial: consts.LessonTrialOn可试听 / consts.LessonTrialOff不可试听
+//
+// 返回: 错误码
+// 调用链: api.EnableLessonTrial/DisableLessonTrial -> service.UpdateLessonTrial -> repo.UpdateLessonTrial
+func (s *Service) UpdateLessonTrial(ctx context.Context, adminUser *common.AdminUser, id int64, enableTrial int32) common.Errno {
+	lesson, errno := s.getLesson(ctx, id)
+	if !errno.IsOk() {
+		return errno
+	}
+	if normalizeLessonTrial(lesson.EnableTrial) == enableTrial {
+		return common.OK
+	}
+
+	err := s.lesson.UpdateLessonTrial(ctx, &do.UpdateLessonTrial{
+		AdminUserID: adminUser.UserID, // 记录操作人ID
+		ID:          id,
+		EnableTrial: enableTrial,
+	})
+	if err != nil {
+		logger.Error("UpdateLessonTrial error", zap.Error(err), zap.Int64("id", id))
+		return common.DatabaseErr.WithErr(err)
+	}
+	return common.OK
+}
+
+// ScheduleLesson 设置课时可见时间
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - req: 设置可见时间请求DTO
+//
+// 返回: 错误码
+// 特性: 设置为未来时间即定时发布,设置为过去时间即立即可见
+// 调用链: api.ScheduleLesson -> service.ScheduleLesson -> repo.UpdateLessonShowTime
+func (s *Service) ScheduleLesson(ctx context.Context, adminUser *common.AdminUser, req *dto.ScheduleLessonReq) common.Errno {
+	if req.ShowTime <= 0 {
+		return common.ParamErr.WithMsg("可见时间不能为空")
+	}
+	if _, errno := s.getLesson(ctx, req.ID); !errno.IsOk() {
+		return errno
+	}
+
+	err := s.lesson.UpdateLessonShowTime(ctx, &do.UpdateLessonShowTime{
+		AdminUserID: adminUser.UserID, // 记录操作人ID
+		ID:          req.ID,
+		ShowTime:    time.UnixMilli(req.ShowTime),
+	})
+	if err != nil {
+		logger.Error("ScheduleLesson error", zap.Error(err), zap.Any("req", req))
+		return common.DatabaseErr.WithErr(err)
+	}
+	return common.OK
+}
+
+// getLesson 根据ID获取课时
+// 返回: 课时对象和错误码,不存在返回DataNotFoundErr
+func (s *Service) getLesson(ctx context.Context, id int64) (*model.CourseLesson, common.Errno) {
+	lesson, err := s.lesson.GetLesson(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.DataNotFoundErr
+		}
+		logger.Error("getLesson GetLesson error", zap.Error(err), zap.Int64("id", id))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	return lesson, common.OK
+}
+
+// checkLessonCatalog 校验目录属于课程商品
+// 返回: 错误码,目录不存在或不属于该课程商品返回DataNotFoundErr
+func (s *Service) checkLessonCatalog(ctx context.Context, goodsID, catalogID int64) common.Errno {
+	catalog, errno := s.getCatalog(ctx, catalogID)
+	if !errno.IsOk() {
+		return errno
+	}
+	if catalog.GoodID != goodsID {
+		return common.DataNotFoundErr.WithMsg("目录不属于该课程")
+	}
+	return common.OK
+}
+
+// normalizeLessonTrial 统一试听标记
+// 规则: 1为可试听,其他值均视为不可试听(-1)
+func normalizeLessonTrial(enableTrial int32) int32 {
+	if enableTrial == consts.LessonTrialOn {
+		return consts.LessonTrialOn
+	}
+	return consts.LessonTrialOff
+}
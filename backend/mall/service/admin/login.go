@@ -1,9 +1,181 @@
 // Package admin 管理员业务逻辑层-登录
 // 职责: 管理员登录相关业务逻辑
-// TODO: 待实现功能
-//   - 手机号+验证码登录
-//   - 手机号+密码登录
-//   - JWT Token生成
-//   - 登录日志记录
-//   - 验证Ticket有效性
+// 登录方式: 手机号+密码(需先通过滑块验证获取Ticket)、手机号+短信验证码
 package admin
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"mall/adaptor/redis"
+	"mall/adaptor/repo/model"
+	"mall/common"
+	"mall/consts"
+	"mall/service/dto"
+	"mall/utils/logger"
+	"mall/utils/password"
+	"time"
+)
+
+// MobilePasswordLogin 手机号+密码登录
+// 参数:
+//   - ctx: 上下文
+//   - req: 登录请求DTO(手机号、密码、滑块Ticket)
+//
+// 返回: 登录响应DTO(Token及上次登录信息)和错误码
+// 业务流程:
+//  1. 校验滑块Ticket(一次性,获取后即删除)
+//  2. 根据手机号查询管理员
+//  3. 校验密码哈希
+//  4. 校验账号状态,历史哈希升级为当前默认算法
+//  5. 记录登录信息并签发Token
+//
+// 错误码: Ticket过期-TicketExpiredErr 密码错误-WrongPasswordErr 账号禁用-UserDisabledErr
+// 调用链: api.MobilePasswordLogin -> service.MobilePasswordLogin
+func (s *Service) MobilePasswordLogin(ctx context.Context, req *dto.MobilePasswordLoginReq) (*dto.AdminLoginResp, common.Errno) {
+	if req.Mobile == "" || req.Password == "" || req.Ticket == "" {
+		return nil, common.ParamErr.WithMsg("手机号、密码和滑块凭证不能为空")
+	}
+
+	// 1. 校验滑块Ticket
+	if errno := s.checkTicket(ctx, req.Ticket); !errno.IsOk() {
+		return nil, errno
+	}
+
+	// 2. 根据手机号查询管理员(不存在时与密码错误返回相同错误码,避免手机号被枚举)
+	user, err := s.adminUser.GetUserByMobile(ctx, req.Mobile)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			password.Verify("", req.Password) // 消耗与真实校验相近的耗时,避免通过响应时间枚举手机号
+			return nil, common.WrongPasswordErr
+		}
+		logger.Error("MobilePasswordLogin GetUserByMobile error", zap.Error(err), zap.String("mobile", req.Mobile))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+
+	// 3. 校验密码(未设置密码的账号只能使用验证码登录)
+	ok, needRehash := password.Verify(user.Password, req.Password)
+	if !ok {
+		return nil, common.WrongPasswordErr
+	}
+
+	// 4. 校验账号状态
+	if user.Status == consts.IsDisable {
+		return nil, common.UserDisabledErr
+	}
+	if needRehash {
+		s.upgradePassword(ctx, user.ID, req.Password)
+	}
+
+	// 5. 记录登录信息并签发Token
+	return s.loginSuccess(ctx, user, req.ClientIP)
+}
+
+// MobileVerifyLogin 手机号+短信验证码登录
+// 参数:
+//   - ctx: 上下文
+//   - req: 登录请求DTO(手机号、短信验证码)
+//
+// 返回: 登录响应DTO(Token及上次登录信息)和错误码
+// 业务流程:
+//  1. 校验短信验证码(一次性,错误次数超限后锁定)
+//  2. 根据手机号查询管理员
+//  3. 校验账号状态
+//  4. 记录登录信息并签发Token
+//
+// 前置: 通过SendSmsCode下发验证码,下发前已校验滑块Ticket
+// 调用链: api.MobileVerifyLogin -> service.MobileVerifyLogin
+func (s *Service) MobileVerifyLogin(ctx context.Context, req *dto.MobileVerifyLoginReq) (*dto.AdminLoginResp, common.Errno) {
+	if req.Mobile == "" || req.SmsCode == "" {
+		return nil, common.ParamErr.WithMsg("手机号和验证码不能为空")
+	}
+
+	// 1. 校验短信验证码
+	if errno := s.checkSmsCode(ctx, consts.SmsSceneAdminLogin, req.Mobile, req.SmsCode); !errno.IsOk() {
+		return nil, errno
+	}
+
+	// 2. 根据手机号查询管理员
+	user, err := s.adminUser.GetUserByMobile(ctx, req.Mobile)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.UserNotFoundErr
+		}
+		logger.Error("MobileVerifyLogin GetUserByMobile error", zap.Error(err), zap.String("mobile", req.Mobile))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+
+	// 3. 校验账号状态
+	if user.Status == consts.IsDisable {
+		return nil, common.UserDisabledErr
+	}
+
+	// 4. 记录登录信息并签发Token
+	return s.loginSuccess(ctx, user, req.ClientIP)
+}
+
+// checkTicket 校验滑块Ticket
+// 参数:
+//   - ctx: 上下文
+//   - ticket: 滑块校验通过后获得的凭证
+//
+// 返回: 错误码,Ticket不存在或已过期返回TicketExpiredErr
+// 特性: Ticket获取后即删除,只能使用一次
+func (s *Service) checkTicket(ctx context.Context, ticket string) common.Errno {
+	captchaKey, err := s.verify.GetCaptchaTicket(ctx, ticket)
+	if err != nil {
+		logger.Error("checkTicket GetCaptchaTicket error", zap.Error(err))
+		return common.RedisErr.WithErr(err)
+	}
+	if captchaKey == "" {
+		return common.TicketExpiredErr
+	}
+	return common.OK
+}
+
+// loginSuccess 登录成功后的公共处理
+// 参数:
+//   - ctx: 上下文
+//   - user: 登录的管理员
+//   - clientIP: 客户端IP
+//
+// 返回: 登录响应DTO和错误码
+// 业务流程:
+//  1. 读取上次登录记录,随响应返回便于管理员发现异常登录
+//  2. 写入本次登录记录(失败仅记录日志,不影响登录)
+//  3. 签发Token
+func (s *Service) loginSuccess(ctx context.Context, user *model.AdminUser, clientIP string) (*dto.AdminLoginResp, common.Errno) {
+	// 1. 读取上次登录记录
+	lastLogin, err := s.loginRecord.GetAdminLastLogin(ctx, user.ID)
+	if err != nil {
+		logger.Warn("loginSuccess GetAdminLastLogin error", zap.Error(err), zap.Int64("user_id", user.ID))
+	}
+
+	// 2. 写入本次登录记录
+	err = s.loginRecord.SetAdminLastLogin(ctx, user.ID, &redis.LastLogin{
+		LoginAt: time.Now().UnixMilli(),
+		IP:      clientIP,
+	})
+	if err != nil {
+		logger.Warn("loginSuccess SetAdminLastLogin error", zap.Error(err), zap.Int64("user_id", user.ID))
+	}
+
+	// 3. 签发Token
+	tokenStr, expire, errno := s.genToken(ctx, user)
+	if !errno.IsOk() {
+		return nil, errno
+	}
+
+	resp := &dto.AdminLoginResp{
+		Token:  tokenStr,
+		Expire: expire,
+		UserID: user.ID,
+		Name:   user.Name,
+	}
+	if lastLogin != nil {
+		resp.LastLoginAt = lastLogin.LoginAt
+		resp.LastLoginIP = lastLogin.IP
+	}
+	return resp, common.OK
+}
@@ -0,0 +1,200 @@
+// Package admin 管理员业务逻辑层-密码
+// 职责: 管理员重置密码、修改密码
+// 安全: 密码使用utils/password加盐哈希存储,兼容校验历史的SHA256哈希并在登录成功后升级
+package admin
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"mall/common"
+	"mall/consts"
+	"mall/service/do"
+	"mall/service/dto"
+	"mall/utils/logger"
+	"mall/utils/password"
+	"unicode"
+)
+
+const (
+	passwordMinLength = 8  // 密码最小长度
+	passwordMaxLength = 32 // 密码最大长度
+)
+
+// ResetPassword 忘记密码时通过短信验证码重置密码
+// 参数:
+//   - ctx: 上下文
+//   - req: 重置密码请求DTO(手机号、短信验证码、新密码)
+//
+// 返回: 错误码
+// 业务流程:
+//  1. 校验新密码强度
+//  2. 校验短信验证码(一次性,错误次数超限后锁定)
+//  3. 根据手机号查询管理员
+//  4. 哈希新密码并更新
+//  5. 吊销该管理员的全部会话
+//
+// 前置: 通过SendSmsCode(重置密码场景)下发验证码,下发前已校验滑块Ticket
+// 调用链: api.ResetPassword -> service.ResetPassword
+func (s *Service) ResetPassword(ctx context.Context, req *dto.ResetPasswordReq) common.Errno {
+	if req.Mobile == "" || req.SmsCode == "" {
+		return common.ParamErr.WithMsg("手机号和验证码不能为空")
+	}
+
+	// 1. 校验新密码强度(先于验证码校验,避免密码不合规时白白消耗验证码)
+	if errno := checkPasswordStrength(req.Password); !errno.IsOk() {
+		return errno
+	}
+
+	// 2. 校验短信验证码
+	if errno := s.checkSmsCode(ctx, consts.SmsSceneAdminResetPassword, req.Mobile, req.SmsCode); !errno.IsOk() {
+		return errno
+	}
+
+	// 3. 根据手机号查询管理员
+	user, err := s.adminUser.GetUserByMobile(ctx, req.Mobile)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return common.UserNotFoundErr
+		}
+		logger.Error("ResetPassword GetUserByMobile error", zap.Error(err), zap.String("mobile", req.Mobile))
+		return common.DatabaseErr.WithErr(err)
+	}
+
+	// 4. 更新密码
+	if errno := s.updatePassword(ctx, user.ID, user.ID, req.Password); !errno.IsOk() {
+		return errno
+	}
+
+	// 5. 吊销全部会话
+	return s.revokeSessions(ctx, user.ID, "")
+}
+
+// ChangePassword 已登录管理员修改自己的密码
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前登录的管理员
+//   - req: 修改密码请求DTO(原密码、新密码)
+//
+// 返回: 错误码
+// 业务流程:
+//  1. 校验新密码强度,且不能与原密码相同
+//  2. 校验原密码
+//  3. 哈希新密码并更新
+//  4. 吊销该管理员除当前会话外的其他会话
+//
+// 调用链: api.ChangePassword -> service.ChangePassword
+func (s *Service) ChangePassword(ctx context.Context, adminUser *common.AdminUser, req *dto.ChangePasswordReq) common.Errno {
+	if req.OldPassword == "" {
+		return common.ParamErr.WithMsg("原密码不能为空")
+	}
+
+	// 1. 校验新密码
+	if errno := checkPasswordStrength(req.NewPassword); !errno.IsOk() {
+		return errno
+	}
+	if req.NewPassword == req.OldPassword {
+		return common.PasswordSameErr
+	}
+
+	// 2. 校验原密码
+	user, err := s.adminUser.GetUserInfo(ctx, adminUser.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return common.UserNotFoundErr
+		}
+		logger.Error("ChangePassword GetUserInfo error", zap.Error(err), zap.Int64("user_id", adminUser.UserID))
+		return common.DatabaseErr.WithErr(err)
+	}
+	if ok, _ := password.Verify(user.Password, req.OldPassword); !ok {
+		return common.WrongPasswordErr.WithMsg("原密码错误")
+	}
+
+	// 3. 更新密码
+	if errno := s.updatePassword(ctx, user.ID, adminUser.UserID, req.NewPassword); !errno.IsOk() {
+		return errno
+	}
+
+	// 4. 吊销其他会话
+	return s.revokeSessions(ctx, user.ID, adminUser.TokenID)
+}
+
+// updatePassword 哈希并更新管理员密码
+// 参数:
+//   - ctx: 上下文
+//   - userID: 被修改密码的管理员ID
+//   - operatorID: 操作人ID
+//   - plain: 新密码明文
+//
+// 返回: 错误码
+func (s *Service) updatePassword(ctx context.Context, userID, operatorID int64, plain string) common.Errno {
+	hashed, err := password.Hash(plain)
+	if err != nil {
+		logger.Error("updatePassword Hash error", zap.Error(err), zap.Int64("user_id", userID))
+		return common.ServerErr.WithErr(err)
+	}
+	err = s.adminUser.UpdateUserPassword(ctx, &do.UpdateUserPassword{
+		AdminUserID: operatorID, // 记录更新人ID
+		ID:          userID,
+		Password:    hashed,
+	})
+	if err != nil {
+		logger.Error("updatePassword UpdateUserPassword error", zap.Error(err), zap.Int64("user_id", userID))
+		return common.DatabaseErr.WithErr(err)
+	}
+	return common.OK
+}
+
+// revokeSessions 吊销管理员会话
+// 参数:
+//   - ctx: 上下文
+//   - userID: 管理员ID
+//   - keepTokenID: 需要保留的会话,为空时吊销全部
+//
+// 返回: 错误码
+func (s *Service) revokeSessions(ctx context.Context, userID int64, keepTokenID string) common.Errno {
+	if err := s.session.RevokeAdminSessions(ctx, userID, keepTokenID); err != nil {
+		logger.Error("revokeSessions RevokeAdminSessions error", zap.Error(err), zap.Int64("user_id", userID))
+		return common.RedisErr.WithErr(err)
+	}
+	return common.OK
+}
+
+// checkPasswordStrength 校验密码强度
+// 规则: 长度8-32位,同时包含字母和数字,不允许空白字符
+func checkPasswordStrength(plain string) common.Errno {
+	if len(plain) < passwordMinLength || len(plain) > passwordMaxLength {
+		return common.PasswordWeakErr
+	}
+	var hasLetter, hasDigit bool
+	for _, r := range plain {
+		switch {
+		case unicode.IsSpace(r):
+			return common.PasswordWeakErr
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasLetter || !hasDigit {
+		return common.PasswordWeakErr
+	}
+	return common.OK
+}
+
+// upgradePassword 登录成功后将历史哈希升级为当前默认算法
+// 参数:
+//   - ctx: 上下文
+//   - userID: 管理员ID
+//   - plain: 本次登录校验通过的密码明文
+//
+// 特性: 升级失败只记录日志,不影响登录,下次登录会再次尝试
+func (s *Service) upgradePassword(ctx context.Context, userID int64, plain string) {
+	if errno := s.updatePassword(ctx, userID, userID, plain); !errno.IsOk() {
+		logger.Warn("upgradePassword error", zap.String("err", errno.ErrMsg), zap.Int64("user_id", userID))
+		return
+	}
+	logger.Info("upgradePassword success", zap.Int64("user_id", userID))
+}
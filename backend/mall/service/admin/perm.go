@@ -0,0 +1,463 @@
+// Package admin 管理员业务逻辑层-权限管理
+// 职责: 权限树的增删改查、启用禁用,查询当前管理员的有效权限
+// 规则:
+//   - 权限编码唯一
+//   - 只有菜单可以作为父级,操作是叶子节点
+//   - 移动节点时不能移动到自身或子孙节点下
+//   - 禁用节点时连同子孙节点一起禁用,启用节点时要求父级已启用
+package admin
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"mall/adaptor/repo/model"
+	"mall/common"
+	"mall/consts"
+	"mall/service/do"
+	"mall/service/dto"
+	"mall/utils/logger"
+)
+
+// GetPermissionTree 获取完整权限树
+// 参数: ctx 上下文
+// 返回: 顶级节点列表(含子节点)和错误码
+// 特性: 包含禁用节点,同级节点按Sort、ID升序
+// 调用链: api.GetPermissionTree -> service.GetPermissionTree -> repo.ListAllPermissions
+func (s *Service) GetPermissionTree(ctx context.Context) ([]*dto.PermNode, common.Errno) {
+	perms, err := s.permission.ListAllPermissions(ctx)
+	if err != nil {
+		logger.Error("GetPermissionTree ListAllPermissions error", zap.Error(err))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	return buildPermTree(perms), common.OK
+}
+
+// GetMyPermissions 获取当前管理员的有效权限
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前登录的管理员
+//
+// 返回: 有效权限编码和菜单树,错误码
+// 规则:
+//   - 超级管理员拥有全部启用中的权限
+//   - 其他管理员为全部启用中角色的权限并集,禁用的权限不生效
+//
+// 用途: 前端渲染侧边栏、控制按钮显示
+// 调用链: api.GetMyPermissions -> service.GetMyPermissions -> listEffectivePermissions
+func (s *Service) GetMyPermissions(ctx context.Context, adminUser *common.AdminUser) (*dto.MyPermissionResp, common.Errno) {
+	perms, _, errno := s.listEffectivePermissions(ctx, adminUser.UserID)
+	if !errno.IsOk() {
+		return nil, errno
+	}
+
+	// 菜单组装为树
+	resp := &dto.MyPermissionResp{
+		Codes: make([]string, 0, len(perms)),
+	}
+	menus := make([]*model.Permission, 0, len(perms))
+	for _, perm := range perms {
+		resp.Codes = append(resp.Codes, perm.Code)
+		if perm.Type == consts.PermTypeMenu {
+			menus = append(menus, perm)
+		}
+	}
+	resp.Menus = buildPermTree(menus)
+	return resp, common.OK
+}
+
+// CreatePermission 创建权限
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - req: 创建权限请求DTO
+//
+// 返回: 权限ID和错误码
+// 业务流程:
+//  1. 校验必填项和类型
+//  2. 校验父级存在且为菜单
+//  3. 校验编码唯一
+//  4. 写入数据库
+//
+// 调用链: api.CreatePermission -> service.CreatePermission -> repo.CreatePermission
+func (s *Service) CreatePermission(ctx context.Context, adminUser *common.AdminUser, req *dto.CreatePermissionReq) (int64, common.Errno) {
+	// 1. 参数校验
+	if errno := checkPermParam(req.Code, req.Name, req.Type); !errno.IsOk() {
+		return 0, errno
+	}
+	parentID := normalizePermParentID(req.ParentID)
+
+	// 2. 父级校验
+	if errno := s.checkPermParent(ctx, parentID); !errno.IsOk() {
+		return 0, errno
+	}
+
+	// 3. 编码唯一性校验
+	if errno := s.checkPermCodeUnique(ctx, req.Code, 0); !errno.IsOk() {
+		return 0, errno
+	}
+
+	// 4. 写入数据库
+	id, err := s.permission.CreatePermission(ctx, &do.CreatePermission{
+		AdminUserID: adminUser.UserID, // 记录操作人ID
+		Code:        req.Code,
+		Type:        req.Type,
+		Name:        req.Name,
+		PagePath:    req.PagePath,
+		ParentID:    parentID,
+		Sort:        req.Sort,
+		Desc:        req.Desc,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return 0, common.PermCodeExistErr // 并发写入时由唯一索引兜底
+		}
+		logger.Error("CreatePermission error", zap.Error(err), zap.Any("req", req))
+		return 0, common.DatabaseErr.WithErr(err)
+	}
+	return id, common.OK
+}
+
+// UpdatePermission 更新权限
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - req: 更新权限请求DTO
+//
+// 返回: 错误码
+// 业务流程:
+//  1. 校验必填项和类型,有子节点的菜单不能改为操作
+//  2. 父级变化时校验新父级存在且为菜单,且不是自身或子孙节点
+//  3. 校验编码唯一
+//  4. 更新数据库
+//
+// 调用链: api.UpdatePermission -> service.UpdatePermission -> repo.UpdatePermission
+func (s *Service) UpdatePermission(ctx context.Context, adminUser *common.AdminUser, req *dto.UpdatePermissionReq) common.Errno {
+	// 1. 参数校验
+	if errno := checkPermParam(req.Code, req.Name, req.Type); !errno.IsOk() {
+		return errno
+	}
+	perm, errno := s.getPermission(ctx, req.ID)
+	if !errno.IsOk() {
+		return errno
+	}
+	if perm.Type == consts.PermTypeMenu && req.Type == consts.PermTypeOperation {
+		count, err := s.permission.CountChildren(ctx, req.ID)
+		if err != nil {
+			logger.Error("UpdatePermission CountChildren error", zap.Error(err), zap.Int64("id", req.ID))
+			return common.DatabaseErr.WithErr(err)
+		}
+		if count > 0 {
+			return common.PermHasChildErr.WithMsg("有子权限的菜单不能改为操作")
+		}
+	}
+
+	// 2. 父级变化时校验父级和环
+	parentID := normalizePermParentID(req.ParentID)
+	if parentID != perm.ParentID {
+		if errno = s.checkPermParent(ctx, parentID); !errno.IsOk() {
+			return errno
+		}
+		if errno = s.checkPermCycle(ctx, req.ID, parentID); !errno.IsOk() {
+			return errno
+		}
+	}
+
+	// 3. 编码唯一性校验
+	if errno = s.checkPermCodeUnique(ctx, req.Code, req.ID); !errno.IsOk() {
+		return errno
+	}
+
+	// 4. 更新数据库
+	err := s.permission.UpdatePermission(ctx, &do.UpdatePermission{
+		AdminUserID: adminUser.UserID, // 记录更新人ID
+		ID:          req.ID,
+		Code:        req.Code,
+		Type:        req.Type,
+		Name:        req.Name,
+		PagePath:    req.PagePath,
+		ParentID:    parentID,
+		Sort:        req.Sort,
+		Desc:        req.Desc,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return common.PermCodeExistErr // 并发写入时由唯一索引兜底
+		}
+		logger.Error("UpdatePermission error", zap.Error(err), zap.Any("req", req))
+		return common.DatabaseErr.WithErr(err)
+	}
+	s.clearPermCache(ctx) // 编码可能变化
+	return common.OK
+}
+
+// UpdatePermissionStatus 启用或禁用权限
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - id: 权限ID
+//   - status: consts.IsEnable启用 / consts.IsDisable禁用
+//
+// 返回: 错误码
+// 规则:
+//   - 启用: 只启用当前节点,父级必须已启用
+//   - 禁用: 当前节点及全部子孙节点一起禁用
+//
+// 调用链: api.EnablePermission/DisablePermission -> service.UpdatePermissionStatus -> repo.UpdatePermissionStatus
+func (s *Service) UpdatePermissionStatus(ctx context.Context, adminUser *common.AdminUser, id int64, status int32) common.Errno {
+	perm, errno := s.getPermission(ctx, id)
+	if !errno.IsOk() {
+		return errno
+	}
+
+	ids := []int64{id}
+	if status == consts.IsEnable {
+		if perm.ParentID != consts.PermRootParentID {
+			parent, errno := s.getPermission(ctx, perm.ParentID)
+			if !errno.IsOk() {
+				return errno
+			}
+			if parent.Status != consts.IsEnable {
+				return common.PermParentOffErr
+			}
+		}
+	} else {
+		perms, err := s.permission.ListAllPermissions(ctx)
+		if err != nil {
+			logger.Error("UpdatePermissionStatus ListAllPermissions error", zap.Error(err))
+			return common.DatabaseErr.WithErr(err)
+		}
+		ids = append(ids, permDescendants(perms, id)...)
+	}
+
+	err := s.permission.UpdatePermissionStatus(ctx, &do.UpdatePermissionStatus{
+		AdminUserID: adminUser.UserID, // 记录更新人ID
+		IDs:         ids,
+		Status:      status,
+	})
+	if err != nil {
+		logger.Error("UpdatePermissionStatus error", zap.Error(err), zap.Int64s("ids", ids))
+		return common.DatabaseErr.WithErr(err)
+	}
+	s.clearPermCache(ctx)
+	return common.OK
+}
+
+// DeletePermission 删除权限
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - req: 权限ID请求DTO
+//
+// 返回: 错误码
+// 规则: 有子权限时不能删除,删除时同时解除与角色的关联
+// 调用链: api.DeletePermission -> service.DeletePermission -> repo.DeletePermission
+func (s *Service) DeletePermission(ctx context.Context, adminUser *common.AdminUser, req *dto.PermissionIDReq) common.Errno {
+	if _, errno := s.getPermission(ctx, req.ID); !errno.IsOk() {
+		return errno
+	}
+	count, err := s.permission.CountChildren(ctx, req.ID)
+	if err != nil {
+		logger.Error("DeletePermission CountChildren error", zap.Error(err), zap.Int64("id", req.ID))
+		return common.DatabaseErr.WithErr(err)
+	}
+	if count > 0 {
+		return common.PermHasChildErr
+	}
+
+	if err = s.permission.DeletePermission(ctx, req.ID); err != nil {
+		logger.Error("DeletePermission error", zap.Error(err), zap.Int64("id", req.ID))
+		return common.DatabaseErr.WithErr(err)
+	}
+	logger.Info("DeletePermission", zap.Int64("id", req.ID), zap.Int64("admin_user_id", adminUser.UserID))
+	s.clearPermCache(ctx)
+	return common.OK
+}
+
+// getPermission 根据ID获取权限
+// 返回: 权限对象和错误码,不存在返回DataNotFoundErr
+func (s *Service) getPermission(ctx context.Context, id int64) (*model.Permission, common.Errno) {
+	perm, err := s.permission.GetPermission(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.DataNotFoundErr
+		}
+		logger.Error("getPermission GetPermission error", zap.Error(err), zap.Int64("id", id))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	return perm, common.OK
+}
+
+// checkPermParent 校验父级权限存在且为菜单
+// 参数:
+//   - ctx: 上下文
+//   - parentID: 父级ID,consts.PermRootParentID表示顶级
+//
+// 返回: 错误码,不满足返回PermParentErr
+func (s *Service) checkPermParent(ctx context.Context, parentID int64) common.Errno {
+	if parentID == consts.PermRootParentID {
+		return common.OK
+	}
+	parent, errno := s.getPermission(ctx, parentID)
+	if errno.Code == common.DataNotFoundErr.Code {
+		return common.PermParentErr
+	}
+	if !errno.IsOk() {
+		return errno
+	}
+	if parent.Type != consts.PermTypeMenu {
+		return common.PermParentErr
+	}
+	return common.OK
+}
+
+// checkPermCycle 校验移动节点不会形成环
+// 参数:
+//   - ctx: 上下文
+//   - id: 被移动的节点ID
+//   - parentID: 新父级ID
+//
+// 返回: 错误码,新父级是自身或子孙节点时返回PermCycleErr
+// 实现: 从新父级沿ParentID向上回溯,途经被移动节点即成环
+func (s *Service) checkPermCycle(ctx context.Context, id, parentID int64) common.Errno {
+	perms, err := s.permission.ListAllPermissions(ctx)
+	if err != nil {
+		logger.Error("checkPermCycle ListAllPermissions error", zap.Error(err))
+		return common.DatabaseErr.WithErr(err)
+	}
+	parents := make(map[int64]int64, len(perms))
+	for _, perm := range perms {
+		parents[perm.ID] = perm.ParentID
+	}
+
+	visited := make(map[int64]bool)
+	for cur := parentID; cur != consts.PermRootParentID; {
+		if cur == id {
+			return common.PermCycleErr
+		}
+		if visited[cur] { // 历史数据已存在环,同样拒绝
+			return common.PermCycleErr
+		}
+		visited[cur] = true
+		next, ok := parents[cur]
+		if !ok {
+			break
+		}
+		cur = next
+	}
+	return common.OK
+}
+
+// checkPermCodeUnique 校验权限编码唯一
+// 参数:
+//   - ctx: 上下文
+//   - code: 权限编码
+//   - excludeID: 排除的权限ID,0表示不排除
+//
+// 返回: 错误码,已存在返回PermCodeExistErr
+func (s *Service) checkPermCodeUnique(ctx context.Context, code string, excludeID int64) common.Errno {
+	perm, err := s.permission.GetPermissionByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return common.OK
+		}
+		logger.Error("checkPermCodeUnique GetPermissionByCode error", zap.Error(err), zap.String("code", code))
+		return common.DatabaseErr.WithErr(err)
+	}
+	if perm.ID != excludeID {
+		return common.PermCodeExistErr
+	}
+	return common.OK
+}
+
+// checkPermParam 校验权限参数
+// 规则: 编码、名称必填,编码不能是保留编码,类型只能是菜单或操作
+func checkPermParam(code, name string, permType int32) common.Errno {
+	if code == "" || name == "" {
+		return common.ParamErr.WithMsg("权限编码和名称不能为空")
+	}
+	if isReservedPermCode(code) {
+		return common.ParamErr.WithMsg("权限编码不能使用保留编码")
+	}
+	if permType != consts.PermTypeMenu && permType != consts.PermTypeOperation {
+		return common.ParamErr.WithMsg("权限类型只能是菜单或操作")
+	}
+	return common.OK
+}
+
+// isReservedPermCode 判断是否为保留的权限编码
+func isReservedPermCode(code string) bool {
+	return code == consts.SuperAdminPermCode
+}
+
+// normalizePermParentID 统一顶级节点的父级ID,未传(0)和负数均视为顶级
+func normalizePermParentID(parentID int64) int64 {
+	if parentID <= 0 {
+		return consts.PermRootParentID
+	}
+	return parentID
+}
+
+// permDescendants 获取节点的全部子孙节点ID
+// 参数:
+//   - perms: 全部权限
+//   - id: 节点ID
+//
+// 返回: 子孙节点ID列表,不含自身
+func permDescendants(perms []*model.Permission, id int64) []int64 {
+	children := make(map[int64][]int64, len(perms))
+	for _, perm := range perms {
+		children[perm.ParentID] = append(children[perm.ParentID], perm.ID)
+	}
+
+	var result []int64
+	visited := map[int64]bool{id: true}
+	queue := []int64{id}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, child := range children[cur] {
+			if visited[child] {
+				continue
+			}
+			visited[child] = true
+			result = append(result, child)
+			queue = append(queue, child)
+		}
+	}
+	return result
+}
+
+// buildPermTree 将权限列表组装为树
+// 参数: perms 权限列表,需已按Sort、ID升序
+// 返回: 顶级节点列表,父级不在列表中的节点作为顶级节点返回
+func buildPermTree(perms []*model.Permission) []*dto.PermNode {
+	nodes := make(map[int64]*dto.PermNode, len(perms))
+	for _, perm := range perms {
+		nodes[perm.ID] = &dto.PermNode{
+			ID:       perm.ID,
+			Code:     perm.Code,
+			Type:     perm.Type,
+			Name:     perm.Name,
+			PagePath: perm.PagePath,
+			ParentID: perm.ParentID,
+			Status:   perm.Status,
+			Sort:     perm.Sort,
+			Desc:     perm.Desc,
+			UpdateAt: perm.UpdateAt.UnixMilli(),
+			Children: []*dto.PermNode{},
+		}
+	}
+
+	roots := make([]*dto.PermNode, 0)
+	for _, perm := range perms {
+		node := nodes[perm.ID]
+		if parent, ok := nodes[perm.ParentID]; ok && perm.ParentID != perm.ID {
+			parent.Children = append(parent.Children, node)
+			continue
+		}
+		roots = append(roots, node)
+	}
+	return roots
+}
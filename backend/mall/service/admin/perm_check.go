@@ -0,0 +1,128 @@
+// Package admin 管理员业务逻辑层-权限校验
+// 职责: 计算管理员的有效权限,校验接口权限,维护权限缓存
+// 规则:
+//   - 有效权限为启用中角色的启用中权限并集
+//   - 超级管理员角色跳过权限校验
+//   - 角色、权限或分配关系变化后清空权限缓存
+package admin
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"mall/adaptor/redis"
+	"mall/adaptor/repo/model"
+	"mall/common"
+	"mall/consts"
+	"mall/utils/logger"
+	"slices"
+)
+
+// CheckPermission 校验管理员是否拥有指定权限
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前登录的管理员
+//   - code: 接口要求的权限编码
+//
+// 返回: 错误码,没有权限返回PermissionErr
+// 业务流程:
+//  1. 读取Redis中的有效权限缓存(权限编码和超级管理员标记)
+//  2. 未命中时从数据库计算有效权限并写入缓存
+//  3. 超级管理员或拥有该编码时通过
+//
+// 特性: Redis异常时降级为直接查询数据库
+// 调用链: router.AdminPermMiddleware -> api.CheckPermission -> service.CheckPermission
+func (s *Service) CheckPermission(ctx context.Context, adminUser *common.AdminUser, code string) common.Errno {
+	// 1. 读取缓存(版本号需在查询数据库之前获取)
+	version, err := s.permCache.GetAdminPermVersion(ctx)
+	cacheOK := err == nil
+	if err != nil {
+		logger.Error("CheckPermission GetAdminPermVersion error", zap.Error(err))
+	}
+	var cached *redis.AdminPerms
+	if cacheOK {
+		cached, err = s.permCache.GetAdminPerms(ctx, version, adminUser.UserID)
+		if err != nil {
+			logger.Error("CheckPermission GetAdminPerms error", zap.Error(err), zap.Int64("user_id", adminUser.UserID))
+			cacheOK = false
+		}
+	}
+
+	// 2. 未命中时查询数据库并写入缓存
+	if cached == nil {
+		perms, isSuper, errno := s.listEffectivePermissions(ctx, adminUser.UserID)
+		if !errno.IsOk() {
+			return errno
+		}
+		cached = &redis.AdminPerms{IsSuper: isSuper}
+		if !isSuper { // 超级管理员跳过权限校验,无需缓存编码
+			cached.Codes = make([]string, 0, len(perms))
+			for _, perm := range perms {
+				cached.Codes = append(cached.Codes, perm.Code)
+			}
+		}
+		if cacheOK {
+			if err = s.permCache.SetAdminPerms(ctx, version, adminUser.UserID, cached); err != nil {
+				logger.Error("CheckPermission SetAdminPerms error", zap.Error(err), zap.Int64("user_id", adminUser.UserID))
+			}
+		}
+	}
+
+	// 3. 校验权限
+	if cached.IsSuper || slices.Contains(cached.Codes, code) {
+		return common.OK
+	}
+	return common.PermissionErr
+}
+
+// listEffectivePermissions 计算管理员的有效权限
+// 参数:
+//   - ctx: 上下文
+//   - userID: 管理员ID
+//
+// 返回: 启用中的权限列表(按Sort、ID升序)、是否超级管理员和错误码
+// 规则: 超级管理员拥有全部启用中的权限,禁用的角色和权限不生效
+// 调用链: service.GetMyPermissions/CheckPermission -> listEffectivePermissions
+func (s *Service) listEffectivePermissions(ctx context.Context, userID int64) ([]*model.Permission, bool, common.Errno) {
+	// 1. 查询启用中的角色
+	roleIDs, err := s.adminUserRole.ListEnabledRoleIDsByUser(ctx, userID)
+	if err != nil {
+		logger.Error("listEffectivePermissions ListEnabledRoleIDsByUser error", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, false, common.DatabaseErr.WithErr(err)
+	}
+
+	// 2. 查询角色拥有的权限
+	isSuper := slices.Contains(roleIDs, consts.SuperAdminRoleID)
+	var perms []*model.Permission
+	if isSuper {
+		perms, err = s.permission.ListAllPermissions(ctx)
+	} else {
+		var permIDs []int64
+		permIDs, err = s.rolePerm.ListPermissionIDsByRoles(ctx, roleIDs)
+		if err == nil {
+			perms, err = s.permission.ListPermissionsByIDs(ctx, permIDs)
+		}
+	}
+	if err != nil {
+		logger.Error("listEffectivePermissions list permissions error", zap.Error(err), zap.Int64("user_id", userID))
+		return nil, false, common.DatabaseErr.WithErr(err)
+	}
+
+	// 3. 过滤禁用的权限
+	result := make([]*model.Permission, 0, len(perms))
+	for _, perm := range perms {
+		if perm.Status == consts.IsEnable {
+			result = append(result, perm)
+		}
+	}
+	return result, isSuper, common.OK
+}
+
+// clearPermCache 清空全部管理员的权限缓存
+// 参数: ctx 上下文
+// 特性: 数据库已修改成功,清空失败只记录日志,缓存在过期后自动更新
+// 调用链: service.UpdateUserRoles/UpdateRolePermissions/UpdateRoleStatus/UpdatePermission/... -> clearPermCache
+func (s *Service) clearPermCache(ctx context.Context) {
+	if err := s.permCache.ClearAdminPerms(ctx); err != nil {
+		logger.Error("clearPermCache ClearAdminPerms error", zap.Error(err))
+	}
+}
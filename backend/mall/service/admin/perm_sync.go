@@ -0,0 +1,99 @@
+// Package admin 管理员业务逻辑层-权限同步
+// 职责: 将路由声明的权限编码同步到permission表
+// 规则:
+//   - 路由不能声明保留编码,否则同步失败
+//   - 表中不存在的编码新增为顶级操作权限(Type=2),名称默认为编码,描述记录对应路由
+//   - 已存在的编码不做修改,保留管理员调整过的名称、父级和排序
+//   - 表中存在但没有任何路由声明的操作权限只报告,不删除
+//   - 多个实例同时启动同步时,编码已被其他实例新增的视为已存在
+package admin
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"mall/common"
+	"mall/consts"
+	"mall/service/do"
+	"mall/service/dto"
+	"mall/utils/logger"
+	"sort"
+	"strings"
+)
+
+// SyncPermissions 同步路由声明的权限编码
+// 参数:
+//   - ctx: 上下文
+//   - routes: 声明了权限编码的路由
+//
+// 返回: 新增和孤立的权限编码,错误码
+// 业务流程:
+//  1. 校验编码并按编码汇总路由(多个路由可共用一个编码)
+//  2. 读取全部权限,新增缺少的操作权限
+//  3. 找出没有路由声明的操作权限
+//
+// 调用链: router.SyncPermissions -> api.SyncPermissions -> service.SyncPermissions
+func (s *Service) SyncPermissions(ctx context.Context, routes []*dto.RoutePerm) (*dto.SyncPermissionResp, common.Errno) {
+	// 1. 按编码汇总路由
+	declared := make(map[string][]string)
+	var codes []string
+	for _, route := range routes {
+		if route.Code == "" || isReservedPermCode(route.Code) {
+			logger.Error("SyncPermissions invalid route code", zap.String("code", route.Code), zap.String("path", route.Path))
+			return nil, common.ParamErr.WithMsg("路由权限编码不能为空或使用保留编码: " + route.Method + " " + route.Path)
+		}
+		if _, ok := declared[route.Code]; !ok {
+			codes = append(codes, route.Code)
+		}
+		declared[route.Code] = append(declared[route.Code], route.Method+" "+route.Path)
+	}
+
+	// 2. 新增缺少的操作权限
+	perms, err := s.permission.ListAllPermissions(ctx)
+	if err != nil {
+		logger.Error("SyncPermissions ListAllPermissions error", zap.Error(err))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	existed := make(map[string]bool, len(perms))
+	for _, perm := range perms {
+		existed[perm.Code] = true
+	}
+	resp := &dto.SyncPermissionResp{
+		Created:  make([]string, 0),
+		Orphaned: make([]string, 0),
+	}
+	for _, code := range codes {
+		if existed[code] {
+			continue
+		}
+		_, err = s.permission.CreatePermission(ctx, &do.CreatePermission{
+			AdminUserID: consts.SystemOperatorID, // 系统同步
+			Code:        code,
+			Type:        consts.PermTypeOperation,
+			Name:        code,
+			ParentID:    consts.PermRootParentID,
+			Desc:        strings.Join(declared[code], ", "),
+		})
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			continue // 其他实例已新增
+		}
+		if err != nil {
+			logger.Error("SyncPermissions CreatePermission error", zap.Error(err), zap.String("code", code))
+			return nil, common.DatabaseErr.WithErr(err)
+		}
+		resp.Created = append(resp.Created, code)
+	}
+
+	// 3. 找出孤立的操作权限
+	for _, perm := range perms {
+		if perm.Type != consts.PermTypeOperation {
+			continue
+		}
+		if _, ok := declared[perm.Code]; !ok {
+			resp.Orphaned = append(resp.Orphaned, perm.Code)
+		}
+	}
+	sort.Strings(resp.Orphaned)
+	return resp, common.OK
+}
@@ -0,0 +1,171 @@
+// Package admin 管理员业务逻辑层-课程商品价格管理
+// 职责: 课程商品价格历史查询、未来价格排期和取消
+// 规则:
+//   - 价格记录在[EffectiveFrom, EffectiveTo)区间内有效,EffectiveTo不传表示长期有效
+//   - 同一时刻有多条有效记录时以EffectiveFrom最晚的为准,限时促销到期后自动回到之前的价格
+//   - 只能排期未来的价格,已生效的记录作为历史保留,不能修改或删除
+//   - 免费商品价格固定为0,不能排期
+package admin
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"mall/adaptor/repo/model"
+	"mall/common"
+	"mall/consts"
+	"mall/service/do"
+	"mall/service/dto"
+	"mall/utils/logger"
+	"time"
+)
+
+// ListGoodsPrices 获取课程商品的价格历史
+// 参数:
+//   - ctx: 上下文
+//   - req: 价格历史请求DTO
+//
+// 返回: 当前价格和价格记录列表(含未来排期),错误码
+// 调用链: api.ListGoodsPrices -> service.ListGoodsPrices -> repo.ListPrices/GetEffectivePrice
+func (s *Service) ListGoodsPrices(ctx context.Context, req *dto.GoodsPriceReq) (*dto.GoodsPriceResp, common.Errno) {
+	g, errno := s.getGoods(ctx, req.GoodsID)
+	if !errno.IsOk() {
+		return nil, errno
+	}
+	prices, err := s.price.ListPrices(ctx, req.GoodsID)
+	if err != nil {
+		logger.Error("ListGoodsPrices ListPrices error", zap.Error(err), zap.Int64("goods_id", req.GoodsID))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	current, errno := s.getEffectivePrice(ctx, g.ID, time.Now())
+	if !errno.IsOk() {
+		return nil, errno
+	}
+
+	resp := &dto.GoodsPriceResp{
+		CurrentPrice: g.CoursePrice, // 没有价格记录的历史商品沿用商品价格
+		List:         make([]*dto.GoodsPriceItem, 0, len(prices)),
+	}
+	if current != nil {
+		resp.CurrentPrice = current.Price
+	}
+	if g.SaleType == consts.SaleTypeFree {
+		resp.CurrentPrice = 0
+	}
+	for _, price := range prices {
+		item := &dto.GoodsPriceItem{
+			ID:            price.ID,
+			Price:         price.Price,
+			EffectiveFrom: price.EffectiveFrom.UnixMilli(),
+			Current:       current != nil && current.ID == price.ID,
+			CreateBy:      price.CreateBy,
+			CreateAt:      price.CreateAt.UnixMilli(),
+		}
+		if price.EffectiveTo != nil {
+			item.EffectiveTo = price.EffectiveTo.UnixMilli()
+		}
+		resp.List = append(resp.List, item)
+	}
+	return resp, common.OK
+}
+
+// ScheduleGoodsPrice 排期课程商品的未来价格
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - req: 排期请求DTO
+//
+// 返回: 价格记录ID和错误码
+// 业务流程:
+//  1. 校验商品存在且为收费商品,价格大于0
+//  2. 校验生效时间晚于当前时间,失效时间晚于生效时间
+//  3. 写入价格记录,到达生效时间后下单按该价格计价
+//
+// 调用链: api.ScheduleGoodsPrice -> service.ScheduleGoodsPrice -> repo.CreatePrice
+func (s *Service) ScheduleGoodsPrice(ctx context.Context, adminUser *common.AdminUser, req *dto.ScheduleGoodsPriceReq) (int64, common.Errno) {
+	// 1. 校验商品和价格
+	g, errno := s.getGoods(ctx, req.GoodsID)
+	if !errno.IsOk() {
+		return 0, errno
+	}
+	if g.SaleType != consts.SaleTypePaid {
+		return 0, common.ParamErr.WithMsg("免费商品不能设置价格")
+	}
+	if req.Price <= 0 {
+		return 0, common.ParamErr.WithMsg("收费商品价格必须大于0")
+	}
+
+	// 2. 校验生效区间
+	effectiveFrom := time.UnixMilli(req.EffectiveFrom)
+	if !effectiveFrom.After(time.Now()) {
+		return 0, common.ParamErr.WithMsg("生效时间必须晚于当前时间")
+	}
+	var effectiveTo *time.Time
+	if req.EffectiveTo > 0 {
+		to := time.UnixMilli(req.EffectiveTo)
+		if !to.After(effectiveFrom) {
+			return 0, common.ParamErr.WithMsg("失效时间必须晚于生效时间")
+		}
+		effectiveTo = &to
+	}
+
+	// 3. 写入价格记录
+	id, err := s.price.CreatePrice(ctx, &do.CreateGoodsPrice{
+		AdminUserID:   adminUser.UserID, // 记录创建人ID
+		GoodsID:       req.GoodsID,
+		Price:         req.Price,
+		EffectiveFrom: effectiveFrom,
+		EffectiveTo:   effectiveTo,
+	})
+	if err != nil {
+		logger.Error("ScheduleGoodsPrice CreatePrice error", zap.Error(err), zap.Any("req", req))
+		return 0, common.DatabaseErr.WithErr(err)
+	}
+	logger.Info("ScheduleGoodsPrice", zap.Int64("id", id), zap.Any("req", req), zap.Int64("admin_user_id", adminUser.UserID))
+	return id, common.OK
+}
+
+// CancelGoodsPrice 取消尚未生效的价格排期
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - req: 价格记录ID请求DTO
+//
+// 返回: 错误码
+// 限制: 已生效的价格记录是下单计价的依据,不能取消
+// 调用链: api.CancelGoodsPrice -> service.CancelGoodsPrice -> repo.DeletePrice
+func (s *Service) CancelGoodsPrice(ctx context.Context, adminUser *common.AdminUser, req *dto.GoodsPriceIDReq) common.Errno {
+	price, err := s.price.GetPrice(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return common.DataNotFoundErr
+		}
+		logger.Error("CancelGoodsPrice GetPrice error", zap.Error(err), zap.Int64("id", req.ID))
+		return common.DatabaseErr.WithErr(err)
+	}
+	if !price.EffectiveFrom.After(time.Now()) {
+		return common.ParamErr.WithMsg("已生效的价格不能取消")
+	}
+
+	if err = s.price.DeletePrice(ctx, req.ID); err != nil {
+		logger.Error("CancelGoodsPrice DeletePrice error", zap.Error(err), zap.Int64("id", req.ID))
+		return common.DatabaseErr.WithErr(err)
+	}
+	logger.Info("CancelGoodsPrice", zap.Int64("id", req.ID), zap.Int64("goods_id", price.GoodsID), zap.Int64("admin_user_id", adminUser.UserID))
+	return common.OK
+}
+
+// getEffectivePrice 获取课程商品当前生效的价格记录
+// 返回: 价格记录和错误码,没有生效的价格记录时返回nil
+func (s *Service) getEffectivePrice(ctx context.Context, goodsID int64, now time.Time) (*model.CourseGoodsPrice, common.Errno) {
+	price, err := s.price.GetEffectivePrice(ctx, goodsID, now)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.OK
+		}
+		logger.Error("getEffectivePrice GetEffectivePrice error", zap.Error(err), zap.Int64("goods_id", goodsID))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	return price, common.OK
+}
@@ -0,0 +1,340 @@
+// Package admin 管理员业务逻辑层-角色管理
+// 职责: 角色的增删改查、启用禁用、分配权限
+// 规则: 角色名称唯一;已分配给管理员的角色不能删除;超级管理员角色不能禁用或删除;
+// 修改超级管理员角色或操作人自己持有的角色(含禁用的角色)的权限需要操作人是超级管理员,防止自行提权
+package admin
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"mall/adaptor/repo/admin"
+	"mall/adaptor/repo/model"
+	"mall/common"
+	"mall/consts"
+	"mall/service/do"
+	"mall/service/dto"
+	"mall/utils/logger"
+	"slices"
+)
+
+// ListRoles 分页查询角色列表
+// 参数:
+//   - ctx: 上下文
+//   - req: 查询条件DTO(含分页参数)
+//
+// 返回: 分页响应和错误码
+// 调用链: api.ListRoles -> service.ListRoles -> repo.ListRoles
+func (s *Service) ListRoles(ctx context.Context, req *dto.ListRoleReq) (*dto.PageResp[*dto.RoleItem], common.Errno) {
+	req.Normalize()
+	roles, total, err := s.role.ListRoles(ctx, &do.ListRole{
+		Offset: req.Offset(),
+		Limit:  req.PageSize,
+		Name:   req.Name,
+		Status: req.Status,
+	})
+	if err != nil {
+		logger.Error("ListRoles error", zap.Error(err), zap.Any("req", req))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	items := make([]*dto.RoleItem, 0, len(roles))
+	for _, role := range roles {
+		items = append(items, &dto.RoleItem{
+			ID:       role.ID,
+			Name:     role.Name,
+			Desc:     role.Desc,
+			Status:   role.Status,
+			CreateBy: role.CreateBy,
+			UpdateBy: role.UpdateBy,
+			CreateAt: role.CreateAt.UnixMilli(),
+			UpdateAt: role.UpdateAt.UnixMilli(),
+		})
+	}
+	return dto.NewPageResp(&req.PageReq, items, total), common.OK
+}
+
+// CreateRole 创建角色
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - req: 创建角色请求DTO
+//
+// 返回: 角色ID和错误码
+// 业务流程:
+//  1. 校验名称必填且唯一
+//  2. 写入数据库,记录创建人和更新人
+//
+// 调用链: api.CreateRole -> service.CreateRole -> repo.CreateRole
+func (s *Service) CreateRole(ctx context.Context, adminUser *common.AdminUser, req *dto.CreateRoleReq) (int64, common.Errno) {
+	// 1. 参数校验
+	if req.Name == "" {
+		return 0, common.ParamErr.WithMsg("角色名称不能为空")
+	}
+	if errno := s.checkRoleNameUnique(ctx, req.Name, 0); !errno.IsOk() {
+		return 0, errno
+	}
+
+	// 2. 写入数据库
+	id, err := s.role.CreateRole(ctx, &do.CreateRole{
+		AdminUserID: adminUser.UserID, // 记录创建人ID
+		Name:        req.Name,
+		Desc:        req.Desc,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return 0, common.RoleNameExistErr // 并发写入时由唯一索引兜底
+		}
+		logger.Error("CreateRole error", zap.Error(err), zap.Any("req", req))
+		return 0, common.DatabaseErr.WithErr(err)
+	}
+	return id, common.OK
+}
+
+// UpdateRole 更新角色
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - req: 更新角色请求DTO
+//
+// 返回: 错误码
+// 可更新字段: 名称、描述
+// 调用链: api.UpdateRole -> service.UpdateRole -> repo.UpdateRole
+func (s *Service) UpdateRole(ctx context.Context, adminUser *common.AdminUser, req *dto.UpdateRoleReq) common.Errno {
+	if req.Name == "" {
+		return common.ParamErr.WithMsg("角色名称不能为空")
+	}
+	if _, errno := s.getRole(ctx, req.ID); !errno.IsOk() {
+		return errno
+	}
+	if errno := s.checkRoleNameUnique(ctx, req.Name, req.ID); !errno.IsOk() {
+		return errno
+	}
+
+	err := s.role.UpdateRole(ctx, &do.UpdateRole{
+		AdminUserID: adminUser.UserID, // 记录更新人ID
+		ID:          req.ID,
+		Name:        req.Name,
+		Desc:        req.Desc,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return common.RoleNameExistErr // 并发写入时由唯一索引兜底
+		}
+		logger.Error("UpdateRole error", zap.Error(err), zap.Any("req", req))
+		return common.DatabaseErr.WithErr(err)
+	}
+	return common.OK
+}
+
+// UpdateRoleStatus 启用或禁用角色
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - id: 角色ID
+//   - status: consts.IsEnable启用 / consts.IsDisable禁用
+//
+// 返回: 错误码
+// 规则: 超级管理员角色不能禁用
+// 调用链: api.EnableRole/DisableRole -> service.UpdateRoleStatus -> repo.UpdateRoleStatus
+func (s *Service) UpdateRoleStatus(ctx context.Context, adminUser *common.AdminUser, id int64, status int32) common.Errno {
+	if status == consts.IsDisable && id == consts.SuperAdminRoleID {
+		return common.SuperAdminRoleErr
+	}
+	role, errno := s.getRole(ctx, id)
+	if !errno.IsOk() {
+		return errno
+	}
+	if role.Status == status {
+		return common.OK
+	}
+
+	err := s.role.UpdateRoleStatus(ctx, &do.UpdateRoleStatus{
+		AdminUserID: adminUser.UserID, // 记录更新人ID
+		ID:          id,
+		Status:      status,
+	})
+	if err != nil {
+		logger.Error("UpdateRoleStatus error", zap.Error(err), zap.Int64("id", id))
+		return common.DatabaseErr.WithErr(err)
+	}
+	s.clearPermCache(ctx) // 禁用的角色不再生效
+	return common.OK
+}
+
+// DeleteRole 删除角色
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - req: 角色ID请求DTO
+//
+// 返回: 错误码
+// 业务流程:
+//  1. 超级管理员角色不能删除
+//  2. 在一个事务中锁定角色,校验角色存在且未分配给任何管理员后删除
+//
+// 调用链: api.DeleteRole -> service.DeleteRole -> repo.DeleteRole
+func (s *Service) DeleteRole(ctx context.Context, adminUser *common.AdminUser, req *dto.RoleIDReq) common.Errno {
+	// 1. 超级管理员角色保护
+	if req.ID == consts.SuperAdminRoleID {
+		return common.SuperAdminRoleErr
+	}
+
+	// 2. 锁定角色,校验未分配后删除
+	if err := s.role.DeleteRole(ctx, req.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return common.DataNotFoundErr
+		}
+		if errors.Is(err, admin.ErrRoleInUse) {
+			return common.RoleInUseErr
+		}
+		logger.Error("DeleteRole error", zap.Error(err), zap.Int64("id", req.ID))
+		return common.DatabaseErr.WithErr(err)
+	}
+	logger.Info("DeleteRole", zap.Int64("id", req.ID), zap.Int64("admin_user_id", adminUser.UserID))
+	return common.OK
+}
+
+// GetRolePermissions 查询角色拥有的权限
+// 参数:
+//   - ctx: 上下文
+//   - req: 角色ID请求DTO
+//
+// 返回: 角色权限ID列表和错误码
+// 调用链: api.GetRolePermissions -> service.GetRolePermissions -> repo.ListPermissionIDsByRoles
+func (s *Service) GetRolePermissions(ctx context.Context, req *dto.RolePermissionReq) (*dto.RolePermissionResp, common.Errno) {
+	if _, errno := s.getRole(ctx, req.ID); !errno.IsOk() {
+		return nil, errno
+	}
+	ids, err := s.rolePerm.ListPermissionIDsByRoles(ctx, []int64{req.ID})
+	if err != nil {
+		logger.Error("GetRolePermissions ListPermissionIDsByRoles error", zap.Error(err), zap.Int64("id", req.ID))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	return &dto.RolePermissionResp{
+		ID:            req.ID,
+		PermissionIDs: ids,
+	}, common.OK
+}
+
+// UpdateRolePermissions 整体替换角色的权限
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - req: 角色权限请求DTO,PermissionIDs为角色最终拥有的全部权限
+//
+// 返回: 错误码
+// 业务流程:
+//  1. 校验角色存在
+//  2. 目标为超级管理员角色或操作人持有的角色时,校验操作人是超级管理员,否则返回PermissionErr
+//  3. 校验权限全部存在
+//  4. 在一个事务中按差异删除、新增关联,清空权限缓存
+//
+// 调用链: api.UpdateRolePermissions -> service.UpdateRolePermissions -> repo.ReplaceRolePermissions
+func (s *Service) UpdateRolePermissions(ctx context.Context, adminUser *common.AdminUser, req *dto.UpdateRolePermissionReq) common.Errno {
+	// 1. 校验角色存在
+	if _, errno := s.getRole(ctx, req.ID); !errno.IsOk() {
+		return errno
+	}
+
+	// 2. 防止自行提权
+	if errno := s.checkRolePermOperator(ctx, adminUser.UserID, req.ID); !errno.IsOk() {
+		return errno
+	}
+
+	// 3. 校验权限存在
+	ids := uniqueIDs(req.PermissionIDs)
+	perms, err := s.permission.ListPermissionsByIDs(ctx, ids)
+	if err != nil {
+		logger.Error("UpdateRolePermissions ListPermissionsByIDs error", zap.Error(err), zap.Any("req", req))
+		return common.DatabaseErr.WithErr(err)
+	}
+	if len(perms) != len(ids) {
+		return common.DataNotFoundErr.WithMsg("权限不存在")
+	}
+
+	// 4. 替换关联
+	err = s.rolePerm.ReplaceRolePermissions(ctx, &do.ReplaceRolePermission{
+		AdminUserID:   adminUser.UserID, // 记录操作人ID
+		RoleID:        req.ID,
+		PermissionIDs: ids,
+	})
+	if err != nil {
+		logger.Error("UpdateRolePermissions ReplaceRolePermissions error", zap.Error(err), zap.Any("req", req))
+		return common.DatabaseErr.WithErr(err)
+	}
+	s.clearPermCache(ctx)
+	return common.OK
+}
+
+// checkRolePermOperator 校验操作人能否修改角色的权限
+// 参数:
+//   - ctx: 上下文
+//   - operatorID: 操作人ID
+//   - roleID: 目标角色ID
+//
+// 返回: 错误码,目标为超级管理员角色或操作人持有的角色且操作人不是超级管理员时返回PermissionErr
+func (s *Service) checkRolePermOperator(ctx context.Context, operatorID, roleID int64) common.Errno {
+	held, err := s.adminUserRole.ListRoleIDsByUser(ctx, operatorID)
+	if err != nil {
+		logger.Error("checkRolePermOperator ListRoleIDsByUser error", zap.Error(err), zap.Int64("user_id", operatorID))
+		return common.DatabaseErr.WithErr(err)
+	}
+	if roleID != consts.SuperAdminRoleID && !slices.Contains(held, roleID) {
+		return common.OK
+	}
+	if !slices.Contains(held, consts.SuperAdminRoleID) {
+		return common.PermissionErr
+	}
+	return common.OK
+}
+
+// getRole 根据ID获取角色
+// 返回: 角色对象和错误码,不存在返回DataNotFoundErr
+func (s *Service) getRole(ctx context.Context, id int64) (*model.Role, common.Errno) {
+	role, err := s.role.GetRole(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.DataNotFoundErr
+		}
+		logger.Error("getRole GetRole error", zap.Error(err), zap.Int64("id", id))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	return role, common.OK
+}
+
+// checkRoleNameUnique 校验角色名称唯一
+// 参数:
+//   - ctx: 上下文
+//   - name: 角色名称
+//   - excludeID: 排除的角色ID,0表示不排除
+//
+// 返回: 错误码,已存在返回RoleNameExistErr
+func (s *Service) checkRoleNameUnique(ctx context.Context, name string, excludeID int64) common.Errno {
+	role, err := s.role.GetRoleByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return common.OK
+		}
+		logger.Error("checkRoleNameUnique GetRoleByName error", zap.Error(err), zap.String("name", name))
+		return common.DatabaseErr.WithErr(err)
+	}
+	if role.ID != excludeID {
+		return common.RoleNameExistErr
+	}
+	return common.OK
+}
+
+// uniqueIDs 过滤非正数ID并去重,保持原有顺序
+func uniqueIDs(ids []int64) []int64 {
+	result := make([]int64, 0, len(ids))
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if id <= 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
@@ -1,6 +1,6 @@
 // Package admin 管理员业务逻辑层
 // 职责: 实现管理员相关的业务逻辑
-// 依赖: adminUser(数据访问) + verify(验证码Redis) + captcha(滑块验证码)
+// 依赖: adminUser/adminUserRole/role/rolePerm/permission(数据访问) + verify(验证码Redis) + captcha(滑块验证码) + token(JWT签发校验) + loginRecord(登录记录) + session(会话) + permCache(权限缓存) + sms(短信) + goods/catalog/lesson/price(课程)
 package admin
 
 import (
@@ -8,14 +8,31 @@ import (
 	"mall/adaptor"
 	"mall/adaptor/redis"
 	"mall/adaptor/repo/admin"
+	"mall/adaptor/repo/course"
+	"mall/adaptor/sms"
 	"mall/utils/captcha"
+	"mall/utils/token"
 )
 
 // Service 管理员服务结构体
 type Service struct {
-	adminUser admin.IAdminUser // 管理员用户数据访问接口
-	verify    redis.IVerify    // 验证码Redis操作接口
-	captcha   slide.Captcha    // 滑块验证码生成器
+	adminUser     admin.IAdminUser         // 管理员用户数据访问接口
+	adminUserRole admin.IAdminUserRole     // 管理员角色关联数据访问接口
+	role          admin.IRole              // 角色数据访问接口
+	rolePerm      admin.IRolePermission    // 角色权限关联数据访问接口
+	permission    admin.IPermission        // 权限数据访问接口
+	verify        redis.IVerify            // 验证码Redis操作接口
+	captcha       slide.Captcha            // 滑块验证码生成器
+	token         *token.Jwt               // 管理员Token签发校验器
+	loginRecord   redis.ILoginRecord       // 登录记录Redis操作接口
+	session       redis.ISession           // 会话Redis操作接口
+	permCache     redis.IPermCache         // 权限缓存Redis操作接口
+	smsTemplate   admin.ISmsTemplate       // 短信模板数据访问接口
+	smsSender     *sms.Sender              // 短信发送器
+	goods         course.ICourseGoods      // 课程商品数据访问接口
+	catalog       course.ICourseCatalog    // 课程目录数据访问接口
+	lesson        course.ICourseLesson     // 课程课时数据访问接口
+	price         course.ICourseGoodsPrice // 课程商品价格数据访问接口
 }
 
 // NewService 创建管理员服务实例
@@ -24,8 +41,22 @@ type Service struct {
 // 调用链: api.NewCtrl -> NewService
 func NewService(adaptor adaptor.IAdaptor) *Service {
 	return &Service{
-		adminUser: admin.NewAdminUser(adaptor),   // 初始化用户数据访问
-		verify:    redis.NewVerify(adaptor),      // 初始化验证码Redis操作
-		captcha:   captcha.NewSlideCaptcha(),     // 初始化滑块验证码生成器
+		adminUser:     admin.NewAdminUser(adaptor),                   // 初始化用户数据访问
+		adminUserRole: admin.NewAdminUserRole(adaptor),               // 初始化管理员角色关联数据访问
+		role:          admin.NewRole(adaptor),                        // 初始化角色数据访问
+		rolePerm:      admin.NewRolePermission(adaptor),              // 初始化角色权限关联数据访问
+		permission:    admin.NewPermission(adaptor),                  // 初始化权限数据访问
+		verify:        redis.NewVerify(adaptor),                      // 初始化验证码Redis操作
+		captcha:       captcha.NewSlideCaptcha(),                     // 初始化滑块验证码生成器
+		token:         token.NewJwt(adaptor.GetConfig().Token.Admin), // 初始化管理员Token签发校验器
+		loginRecord:   redis.NewLoginRecord(adaptor),                 // 初始化登录记录Redis操作
+		session:       redis.NewSession(adaptor),                     // 初始化会话Redis操作
+		permCache:     redis.NewPermCache(adaptor),                   // 初始化权限缓存Redis操作
+		smsTemplate:   admin.NewSmsTemplate(adaptor),                 // 初始化短信模板数据访问
+		smsSender:     sms.NewSender(adaptor.GetConfig().Sms),        // 初始化短信发送器
+		goods:         course.NewCourseGoods(adaptor),                // 初始化课程商品数据访问
+		catalog:       course.NewCourseCatalog(adaptor),              // 初始化课程目录数据访问
+		lesson:        course.NewCourseLesson(adaptor),               // 初始化课程课时数据访问
+		price:         course.NewCourseGoodsPrice(adaptor),           // 初始化课程商品价格数据访问
 	}
 }
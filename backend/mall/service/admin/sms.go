@@ -0,0 +1,182 @@
+// Package admin 管理员业务逻辑层-短信验证码
+// 职责: 短信验证码的下发与校验
+// 规则:
+//   - 验证码6位数字,有效期5分钟,只能使用一次
+//   - 同一手机号60秒内只能下发一次
+//   - 最多校验5次(含正确的一次),超过后锁定,重新下发不清空尝试次数,最后一次校验5分钟后解除
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"mall/common"
+	"mall/service/dto"
+	"mall/utils/logger"
+	"mall/utils/tools"
+	"strconv"
+	"time"
+)
+
+const (
+	smsCodeLength      = 6               // 验证码位数
+	smsCodeExpire      = time.Minute * 5 // 验证码有效期
+	smsCodeMaxAttempts = 5               // 最大尝试次数,超过后锁定
+	smsSendCooldown    = time.Minute     // 同一手机号下发间隔
+)
+
+// SendSmsCode 下发短信验证码
+// 参数:
+//   - ctx: 上下文
+//   - scene: 业务场景编码(consts.SmsSceneXxx)
+//   - req: 下发请求DTO(手机号、滑块Ticket)
+//
+// 返回: 下发响应DTO和错误码
+// 业务流程:
+//  1. 校验滑块Ticket(一次性)
+//  2. 设置下发冷却,冷却中返回SmsFrequentErr(手机号是否存在都会设置,避免手机号被枚举)
+//  3. 校验手机号属于未删除的管理员,不属于时直接返回成功但不下发,避免手机号被枚举
+//  4. 生成验证码存入Redis(不重置尝试次数)
+//  5. 发送短信
+//
+// 调用链: api.SendSmsCode -> service.SendSmsCode
+func (s *Service) SendSmsCode(ctx context.Context, scene string, req *dto.SendSmsCodeReq) (*dto.SendSmsCodeResp, common.Errno) {
+	if req.Mobile == "" || req.Ticket == "" {
+		return nil, common.ParamErr.WithMsg("手机号和滑块凭证不能为空")
+	}
+	resp := &dto.SendSmsCodeResp{Expire: int64(smsCodeExpire.Seconds())}
+
+	// 1. 校验滑块Ticket
+	if errno := s.checkTicket(ctx, req.Ticket); !errno.IsOk() {
+		return nil, errno
+	}
+
+	// 2. 设置下发冷却
+	locked, err := s.verify.LockSmsSend(ctx, req.Mobile, smsSendCooldown)
+	if err != nil {
+		logger.Error("SendSmsCode LockSmsSend error", zap.Error(err), zap.String("mobile", req.Mobile))
+		return nil, common.RedisErr.WithErr(err)
+	}
+	if !locked {
+		return nil, common.SmsFrequentErr
+	}
+
+	// 3. 校验手机号
+	_, err = s.adminUser.GetUserByMobile(ctx, req.Mobile)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("SendSmsCode mobile not found", zap.String("mobile", req.Mobile), zap.String("scene", scene))
+			return resp, common.OK
+		}
+		logger.Error("SendSmsCode GetUserByMobile error", zap.Error(err), zap.String("mobile", req.Mobile))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+
+	// 4. 生成验证码存入Redis
+	code := tools.RandomDigits(smsCodeLength)
+	err = s.verify.SetSmsCode(ctx, scene, req.Mobile, code, smsCodeExpire)
+	if err != nil {
+		logger.Error("SendSmsCode SetSmsCode error", zap.Error(err), zap.String("mobile", req.Mobile))
+		return nil, common.RedisErr.WithErr(err)
+	}
+
+	// 5. 发送短信
+	if errno := s.sendSmsCode(ctx, scene, req.Mobile, code); !errno.IsOk() {
+		return nil, errno
+	}
+	return resp, common.OK
+}
+
+// sendSmsCode 发送短信验证码
+// 参数:
+//   - ctx: 上下文
+//   - scene: 业务场景编码
+//   - mobile: 手机号
+//   - code: 验证码
+//
+// 返回: 错误码
+// 模板参数: {1}验证码 {2}有效期(分钟)
+func (s *Service) sendSmsCode(ctx context.Context, scene, mobile, code string) common.Errno {
+	return s.sendSms(ctx, scene, mobile, []string{code, strconv.Itoa(int(smsCodeExpire.Minutes()))})
+}
+
+// sendSms 按场景发送短信
+// 参数:
+//   - ctx: 上下文
+//   - scene: 业务场景编码,对应sms_template.scene_code
+//   - mobile: 手机号
+//   - params: 模板参数
+//
+// 返回: 错误码,场景未配置启用中的模板返回SmsTemplateErr
+// 调用链: service.sendSmsCode -> sendSms -> sms.Sender.Send
+func (s *Service) sendSms(ctx context.Context, scene, mobile string, params []string) common.Errno {
+	tmpl, err := s.smsTemplate.GetTemplateByScene(ctx, scene)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("sendSms template not found", zap.String("scene", scene))
+			return common.SmsTemplateErr
+		}
+		logger.Error("sendSms GetTemplateByScene error", zap.Error(err), zap.String("scene", scene))
+		return common.DatabaseErr.WithErr(err)
+	}
+	if err = s.smsSender.Send(ctx, tmpl, mobile, params); err != nil {
+		return common.SmsSendErr.WithErr(err)
+	}
+	return common.OK
+}
+
+// checkSmsCode 校验短信验证码
+// 参数:
+//   - ctx: 上下文
+//   - scene: 业务场景编码
+//   - mobile: 手机号
+//   - code: 用户输入的验证码
+//
+// 返回: 错误码
+// 业务流程:
+//  1. 先原子累加尝试次数,超过上限返回SmsCodeLimitErr,并发猜测时超出上限的请求不会进入比较
+//  2. 验证码不存在或已过期返回SmsCodeExpiredErr
+//  3. 不一致时返回SmsCodeWrongErr,已达上限返回SmsCodeLimitErr(比较使用常量时间)
+//  4. 一致时删除验证码,删除成功才清空尝试次数,并发提交时只有删除成功的一方通过
+func (s *Service) checkSmsCode(ctx context.Context, scene, mobile, code string) common.Errno {
+	// 1. 累加尝试次数
+	attempts, err := s.verify.IncrSmsCodeAttempt(ctx, scene, mobile, smsCodeExpire)
+	if err != nil {
+		logger.Error("checkSmsCode IncrSmsCodeAttempt error", zap.Error(err), zap.String("mobile", mobile))
+		return common.RedisErr.WithErr(err)
+	}
+	if attempts > smsCodeMaxAttempts {
+		return common.SmsCodeLimitErr
+	}
+
+	// 2. 获取验证码
+	expect, err := s.verify.GetSmsCode(ctx, scene, mobile)
+	if err != nil {
+		logger.Error("checkSmsCode GetSmsCode error", zap.Error(err), zap.String("mobile", mobile))
+		return common.RedisErr.WithErr(err)
+	}
+	if expect == "" {
+		return common.SmsCodeExpiredErr
+	}
+
+	// 3. 校验不通过
+	if subtle.ConstantTimeCompare([]byte(code), []byte(expect)) != 1 {
+		if attempts >= smsCodeMaxAttempts {
+			return common.SmsCodeLimitErr
+		}
+		return common.SmsCodeWrongErr
+	}
+
+	// 4. 校验通过,删除验证码
+	deleted, err := s.verify.DelSmsCode(ctx, scene, mobile)
+	if err != nil {
+		logger.Error("checkSmsCode DelSmsCode error", zap.Error(err), zap.String("mobile", mobile))
+		return common.RedisErr.WithErr(err)
+	}
+	if !deleted {
+		return common.SmsCodeExpiredErr
+	}
+	return common.OK
+}
@@ -0,0 +1,259 @@
+// Package admin 管理员业务逻辑层-短信模板管理
+// 职责: 短信模板的增删改查、启用禁用和测试发送
+// 规则: 同一场景编码只能有一个启用中的模板
+package admin
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"mall/adaptor/repo/model"
+	"mall/adaptor/sms"
+	"mall/common"
+	"mall/consts"
+	"mall/service/do"
+	"mall/service/dto"
+	"mall/utils/logger"
+)
+
+// ListSmsTemplates 查询短信模板列表
+// 参数:
+//   - ctx: 上下文
+//   - req: 查询条件DTO
+//
+// 返回: 模板列表和错误码
+// 调用链: api.ListSmsTemplates -> service.ListSmsTemplates -> repo.ListTemplates
+func (s *Service) ListSmsTemplates(ctx context.Context, req *dto.ListSmsTemplateReq) ([]*dto.SmsTemplateItem, common.Errno) {
+	list, err := s.smsTemplate.ListTemplates(ctx, &do.ListSmsTemplate{
+		SceneCode: req.SceneCode,
+		Platform:  req.Platform,
+		Status:    req.Status,
+	})
+	if err != nil {
+		logger.Error("ListSmsTemplates ListTemplates error", zap.Error(err), zap.Any("req", req))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	items := make([]*dto.SmsTemplateItem, 0, len(list))
+	for _, tmpl := range list {
+		items = append(items, &dto.SmsTemplateItem{
+			ID:             tmpl.ID,
+			SceneCode:      tmpl.SceneCode,
+			SignName:       tmpl.SignName,
+			PlatformTmplID: tmpl.PlatformTmplID,
+			TmplStr:        tmpl.TmplStr,
+			Platform:       tmpl.Platform,
+			Status:         tmpl.Status,
+			AdminUserID:    tmpl.AdminUserID,
+			CreateAt:       tmpl.CreateAt.UnixMilli(),
+			UpdateAt:       tmpl.UpdateAt.UnixMilli(),
+		})
+	}
+	return items, common.OK
+}
+
+// CreateSmsTemplate 创建短信模板
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - req: 创建模板请求DTO
+//
+// 返回: 模板ID和错误码
+// 业务流程:
+//  1. 校验必填项和平台编码
+//  2. 新模板默认启用,校验场景下没有其他启用中的模板
+//  3. 写入数据库并记录操作人
+//
+// 调用链: api.CreateSmsTemplate -> service.CreateSmsTemplate -> repo.CreateTemplate
+func (s *Service) CreateSmsTemplate(ctx context.Context, adminUser *common.AdminUser, req *dto.CreateSmsTemplateReq) (int64, common.Errno) {
+	// 1. 参数校验
+	if errno := checkSmsTemplateParam(req.SceneCode, req.SignName, req.TmplStr, req.Platform); !errno.IsOk() {
+		return 0, errno
+	}
+
+	// 2. 场景唯一性校验
+	if errno := s.checkSmsSceneUnique(ctx, req.SceneCode, 0); !errno.IsOk() {
+		return 0, errno
+	}
+
+	// 3. 写入数据库
+	id, err := s.smsTemplate.CreateTemplate(ctx, &do.CreateSmsTemplate{
+		AdminUserID:    adminUser.UserID, // 记录操作人
+		SceneCode:      req.SceneCode,
+		SignName:       req.SignName,
+		PlatformTmplID: req.PlatformTmplID,
+		TmplStr:        req.TmplStr,
+		Platform:       req.Platform,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return 0, common.SmsSceneExistErr // 并发写入时由唯一索引兜底
+		}
+		logger.Error("CreateSmsTemplate CreateTemplate error", zap.Error(err), zap.Any("req", req))
+		return 0, common.DatabaseErr.WithErr(err)
+	}
+	return id, common.OK
+}
+
+// UpdateSmsTemplate 更新短信模板
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - req: 更新模板请求DTO
+//
+// 返回: 错误码
+// 业务流程:
+//  1. 校验必填项和平台编码
+//  2. 模板启用中且修改了场景编码时,校验新场景下没有其他启用中的模板
+//  3. 更新数据库并记录操作人
+//
+// 调用链: api.UpdateSmsTemplate -> service.UpdateSmsTemplate -> repo.UpdateTemplate
+func (s *Service) UpdateSmsTemplate(ctx context.Context, adminUser *common.AdminUser, req *dto.UpdateSmsTemplateReq) common.Errno {
+	// 1. 参数校验
+	if errno := checkSmsTemplateParam(req.SceneCode, req.SignName, req.TmplStr, req.Platform); !errno.IsOk() {
+		return errno
+	}
+	tmpl, errno := s.getSmsTemplate(ctx, req.ID)
+	if !errno.IsOk() {
+		return errno
+	}
+
+	// 2. 场景唯一性校验
+	if tmpl.Status == consts.IsEnable && tmpl.SceneCode != req.SceneCode {
+		if errno = s.checkSmsSceneUnique(ctx, req.SceneCode, req.ID); !errno.IsOk() {
+			return errno
+		}
+	}
+
+	// 3. 更新数据库
+	err := s.smsTemplate.UpdateTemplate(ctx, &do.UpdateSmsTemplate{
+		AdminUserID:    adminUser.UserID, // 记录操作人
+		ID:             req.ID,
+		SceneCode:      req.SceneCode,
+		SignName:       req.SignName,
+		PlatformTmplID: req.PlatformTmplID,
+		TmplStr:        req.TmplStr,
+		Platform:       req.Platform,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return common.SmsSceneExistErr // 并发写入时由唯一索引兜底
+		}
+		logger.Error("UpdateSmsTemplate UpdateTemplate error", zap.Error(err), zap.Any("req", req))
+		return common.DatabaseErr.WithErr(err)
+	}
+	return common.OK
+}
+
+// UpdateSmsTemplateStatus 启用或禁用短信模板
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - id: 模板ID
+//   - status: consts.IsEnable启用 / consts.IsDisable禁用
+//
+// 返回: 错误码
+// 规则: 启用时校验场景下没有其他启用中的模板
+// 调用链: api.EnableSmsTemplate/DisableSmsTemplate -> service.UpdateSmsTemplateStatus -> repo.UpdateTemplateStatus
+func (s *Service) UpdateSmsTemplateStatus(ctx context.Context, adminUser *common.AdminUser, id int64, status int32) common.Errno {
+	tmpl, errno := s.getSmsTemplate(ctx, id)
+	if !errno.IsOk() {
+		return errno
+	}
+	if tmpl.Status == status {
+		return common.OK
+	}
+	if status == consts.IsEnable {
+		if errno = s.checkSmsSceneUnique(ctx, tmpl.SceneCode, id); !errno.IsOk() {
+			return errno
+		}
+	}
+
+	err := s.smsTemplate.UpdateTemplateStatus(ctx, &do.UpdateSmsTemplateStatus{
+		AdminUserID: adminUser.UserID, // 记录操作人
+		ID:          id,
+		Status:      status,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return common.SmsSceneExistErr // 并发写入时由唯一索引兜底
+		}
+		logger.Error("UpdateSmsTemplateStatus UpdateTemplateStatus error", zap.Error(err), zap.Int64("id", id))
+		return common.DatabaseErr.WithErr(err)
+	}
+	return common.OK
+}
+
+// SendTestSms 使用指定模板发送测试短信
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - req: 测试发送请求DTO(模板ID、手机号、示例参数)
+//
+// 返回: 渲染后的短信内容和错误码
+// 特性: 不区分模板状态,便于启用前验证;通过模板配置的平台真实发送(Fake配置开启时走假通道)
+// 调用链: api.SendTestSms -> service.SendTestSms -> sms.Sender.Send
+func (s *Service) SendTestSms(ctx context.Context, adminUser *common.AdminUser, req *dto.SendTestSmsReq) (*dto.SendTestSmsResp, common.Errno) {
+	if req.Mobile == "" {
+		return nil, common.ParamErr.WithMsg("手机号不能为空")
+	}
+	tmpl, errno := s.getSmsTemplate(ctx, req.ID)
+	if !errno.IsOk() {
+		return nil, errno
+	}
+
+	logger.Info("SendTestSms", zap.Int64("admin_user_id", adminUser.UserID), zap.Int64("template_id", tmpl.ID), zap.String("mobile", req.Mobile))
+	if err := s.smsSender.Send(ctx, tmpl, req.Mobile, req.Params); err != nil {
+		return nil, common.SmsSendErr.WithErr(err)
+	}
+	return &dto.SendTestSmsResp{
+		Content: sms.Render(tmpl.TmplStr, req.Params),
+	}, common.OK
+}
+
+// getSmsTemplate 根据ID获取短信模板
+// 返回: 模板对象和错误码,不存在返回DataNotFoundErr
+func (s *Service) getSmsTemplate(ctx context.Context, id int64) (*model.SmsTemplate, common.Errno) {
+	tmpl, err := s.smsTemplate.GetTemplate(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.DataNotFoundErr
+		}
+		logger.Error("getSmsTemplate GetTemplate error", zap.Error(err), zap.Int64("id", id))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	return tmpl, common.OK
+}
+
+// checkSmsSceneUnique 校验场景下没有其他启用中的模板
+// 参数:
+//   - ctx: 上下文
+//   - sceneCode: 场景编码
+//   - excludeID: 排除的模板ID,0表示不排除
+//
+// 返回: 错误码,已存在返回SmsSceneExistErr
+func (s *Service) checkSmsSceneUnique(ctx context.Context, sceneCode string, excludeID int64) common.Errno {
+	count, err := s.smsTemplate.CountEnabledByScene(ctx, sceneCode, excludeID)
+	if err != nil {
+		logger.Error("checkSmsSceneUnique CountEnabledByScene error", zap.Error(err), zap.String("scene", sceneCode))
+		return common.DatabaseErr.WithErr(err)
+	}
+	if count > 0 {
+		return common.SmsSceneExistErr
+	}
+	return common.OK
+}
+
+// checkSmsTemplateParam 校验短信模板参数
+// 规则: 场景编码、签名、模板内容必填,平台只能是tencent/ronglian/fake
+func checkSmsTemplateParam(sceneCode, signName, tmplStr, platform string) common.Errno {
+	if sceneCode == "" || signName == "" || tmplStr == "" {
+		return common.ParamErr.WithMsg("场景编码、签名和模板内容不能为空")
+	}
+	switch platform {
+	case sms.PlatformTencent, sms.PlatformRonglian, sms.PlatformFake:
+		return common.OK
+	default:
+		return common.ParamErr.WithMsg("不支持的短信平台")
+	}
+}
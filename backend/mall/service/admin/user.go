@@ -7,10 +7,13 @@ import (
 	"errors"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"mall/adaptor/repo/admin"
 	"mall/common"
+	"mall/consts"
 	"mall/service/do"
 	"mall/service/dto"
 	"mall/utils/logger"
+	"time"
 )
 
 // CreateUser 创建管理员用户
@@ -20,11 +23,15 @@ import (
 //   - req: 创建用户请求DTO
 // 返回: 用户ID和错误码
 // 业务流程:
-//   1. 转换DTO为DO对象
-//   2. 记录操作人ID
-//   3. 调用数据访问层创建用户
+//   1. 校验手机号未被未删除的管理员使用
+//   2. 转换DTO为DO对象
+//   3. 记录操作人ID
+//   4. 调用数据访问层创建用户
 // 调用链: api.CreateUser -> service.CreateUser -> repo.CreateUser
 func (s *Service) CreateUser(ctx context.Context, adminUser *common.AdminUser, req *dto.CreateUserReq) (int64, common.Errno) {
+	if errno := s.checkMobileUnique(ctx, req.Mobile, 0); !errno.IsOk() {
+		return 0, errno
+	}
 	userID, err := s.adminUser.CreateUser(ctx, &do.CreateUser{
 		AdminUserID: adminUser.UserID, // 记录创建人ID
 		Name:        req.Name,
@@ -33,6 +40,9 @@ func (s *Service) CreateUser(ctx context.Context, adminUser *common.AdminUser, r
 		Sex:         req.Sex,
 	})
 	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return 0, common.MobileExistErr // 并发写入时由唯一索引兜底
+		}
 		logger.Error("CreateUser error", zap.Error(err), zap.Any("req", req))
 		return 0, common.DatabaseErr.WithErr(err)
 	}
@@ -69,17 +79,52 @@ func (s *Service) UpdateUser(ctx context.Context, adminUser *common.AdminUser, r
 //   - req: 更新状态请求DTO
 // 返回: 错误码
 // 状态值: 1(启用) / -1(禁用)
+// 业务流程:
+//   1. 校验状态值,禁止禁用自己
+//   2. 查询目标管理员(已删除的管理员查询不到)
+//   3. 更新状态,禁用时在同一事务中锁定启用中的超级管理员,至少保留一个
+//   4. 禁用时吊销目标管理员的全部会话,Token立即失效
 // 调用链: api.UpdateUserStatus -> service.UpdateUserStatus -> repo.UpdateUserStatus
 func (s *Service) UpdateUserStatus(ctx context.Context, adminUser *common.AdminUser, req *dto.UpdateUserStatusReq) common.Errno {
-	err := s.adminUser.UpdateUserStatus(ctx, &do.UpdateUserStatus{
+	// 1. 参数校验
+	if req.Status != consts.IsEnable && req.Status != consts.IsDisable {
+		return common.ParamErr.WithMsg("状态值错误")
+	}
+	if req.Status == consts.IsDisable && req.ID == adminUser.UserID {
+		return common.DisableSelfErr
+	}
+
+	// 2. 查询目标管理员
+	user, err := s.adminUser.GetUserInfo(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return common.UserNotFoundErr
+		}
+		logger.Error("UpdateUserStatus GetUserInfo error", zap.Error(err), zap.Any("req", req))
+		return common.DatabaseErr.WithErr(err)
+	}
+	if user.Status == req.Status {
+		return common.OK
+	}
+
+	// 3. 更新状态,禁用时在同一事务中保留最后一个启用中的超级管理员
+	err = s.adminUser.UpdateUserStatus(ctx, &do.UpdateUserStatus{
 		ID:          req.ID,
 		Status:      req.Status,
 		AdminUserID: adminUser.UserID, // 记录更新人ID
 	})
 	if err != nil {
+		if errors.Is(err, admin.ErrLastSuperAdmin) {
+			return common.LastSuperAdminErr
+		}
 		logger.Error("UpdateUserStatus error", zap.Error(err), zap.Any("req", req))
 		return common.DatabaseErr.WithErr(err)
 	}
+
+	// 4. 禁用时吊销全部会话
+	if req.Status == consts.IsDisable {
+		return s.revokeSessions(ctx, user.ID, "")
+	}
 	return common.OK
 }
 
@@ -88,11 +133,9 @@ func (s *Service) UpdateUserStatus(ctx context.Context, adminUser *common.AdminU
 //   - ctx: 上下文
 //   - adminUser: 当前登录的管理员
 // 返回: 用户信息DTO和错误码
-// TODO: 当前写死查询ID=1,应改为查询当前用户
 // 调用链: api.GetUserInfo -> service.GetUserInfo -> repo.GetUserInfo
 func (s *Service) GetUserInfo(ctx context.Context, adminUser *common.AdminUser) (*dto.UserInfoResp, common.Errno) {
-	// TODO: 应该查询adminUser.UserID,而不是写死1
-	user, err := s.adminUser.GetUserInfo(ctx, 1)
+	user, err := s.adminUser.GetUserInfo(ctx, adminUser.UserID)
 	if err != nil {
 		// 用户不存在
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -106,3 +149,177 @@ func (s *Service) GetUserInfo(ctx context.Context, adminUser *common.AdminUser)
 		UserID: user.ID,
 	}, common.OK
 }
+
+// ListUsers 分页查询管理员列表
+// 参数:
+//   - ctx: 上下文
+//   - req: 查询条件DTO(含分页参数)
+// 返回: 分页响应和错误码
+// 排序: order_by支持create_at/update_at,默认create_at;order支持asc/desc,默认desc
+// 调用链: api.ListUsers -> service.ListUsers -> repo.ListUsers
+func (s *Service) ListUsers(ctx context.Context, req *dto.ListUserReq) (*dto.PageResp[*dto.UserItem], common.Errno) {
+	req.Normalize()
+	if req.OrderBy != "" && req.OrderBy != "create_at" && req.OrderBy != "update_at" {
+		return nil, common.ParamErr.WithMsg("排序字段只支持create_at/update_at")
+	}
+	if req.Order != "" && req.Order != "asc" && req.Order != "desc" {
+		return nil, common.ParamErr.WithMsg("排序方向只支持asc/desc")
+	}
+
+	cond := &do.ListUser{
+		Offset:  req.Offset(),
+		Limit:   req.PageSize,
+		Name:    req.Name,
+		Mobile:  req.Mobile,
+		Status:  req.Status,
+		Sex:     req.Sex,
+		OrderBy: req.OrderBy,
+		Desc:    req.Order != "asc",
+	}
+	if req.CreateStart > 0 {
+		cond.CreateStart = time.UnixMilli(req.CreateStart)
+	}
+	if req.CreateEnd > 0 {
+		cond.CreateEnd = time.UnixMilli(req.CreateEnd)
+	}
+
+	users, total, err := s.adminUser.ListUsers(ctx, cond)
+	if err != nil {
+		logger.Error("ListUsers error", zap.Error(err), zap.Any("req", req))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	items := make([]*dto.UserItem, 0, len(users))
+	for _, user := range users {
+		items = append(items, &dto.UserItem{
+			UserID:   user.ID,
+			Name:     user.Name,
+			NickName: user.NickName,
+			Mobile:   user.Mobile,
+			Sex:      user.Sex,
+			Status:   user.Status,
+			CreateAt: user.CreateAt.UnixMilli(),
+			UpdateAt: user.UpdateAt.UnixMilli(),
+		})
+	}
+	return dto.NewPageResp(&req.PageReq, items, total), common.OK
+}
+
+// DeleteUser 软删除管理员用户
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - req: 用户ID请求DTO
+// 返回: 错误码
+// 业务流程:
+//   1. 禁止删除自己
+//   2. 查询目标管理员
+//   3. 标记删除并吊销目标管理员的全部会话,删除时在同一事务中锁定启用中的超级管理员,至少保留一个
+// 调用链: api.DeleteUser -> service.DeleteUser -> repo.DeleteUser
+func (s *Service) DeleteUser(ctx context.Context, adminUser *common.AdminUser, req *dto.UserIDReq) common.Errno {
+	// 1. 禁止删除自己
+	if req.ID == adminUser.UserID {
+		return common.DeleteSelfErr
+	}
+
+	// 2. 查询目标管理员
+	user, err := s.adminUser.GetUserInfo(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return common.UserNotFoundErr
+		}
+		logger.Error("DeleteUser GetUserInfo error", zap.Error(err), zap.Any("req", req))
+		return common.DatabaseErr.WithErr(err)
+	}
+
+	// 3. 标记删除并吊销会话,在同一事务中保留最后一个启用中的超级管理员
+	err = s.adminUser.DeleteUser(ctx, &do.DeleteUser{
+		AdminUserID: adminUser.UserID, // 记录更新人ID
+		ID:          req.ID,
+	})
+	if err != nil {
+		if errors.Is(err, admin.ErrLastSuperAdmin) {
+			return common.LastSuperAdminErr
+		}
+		logger.Error("DeleteUser error", zap.Error(err), zap.Any("req", req))
+		return common.DatabaseErr.WithErr(err)
+	}
+	return s.revokeSessions(ctx, user.ID, "")
+}
+
+// RestoreUser 恢复已删除的管理员用户
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员,必须是超级管理员
+//   - req: 用户ID请求DTO
+// 返回: 错误码
+// 业务流程:
+//   1. 校验操作人是超级管理员
+//   2. 查询已删除的目标管理员
+//   3. 校验手机号未被其他未删除的管理员使用
+//   4. 取消删除标记,状态保持删除前的值
+// 调用链: api.RestoreUser -> service.RestoreUser -> repo.RestoreUser
+func (s *Service) RestoreUser(ctx context.Context, adminUser *common.AdminUser, req *dto.UserIDReq) common.Errno {
+	// 1. 校验超级管理员
+	isSuper, err := s.adminUserRole.HasRole(ctx, adminUser.UserID, consts.SuperAdminRoleID)
+	if err != nil {
+		logger.Error("RestoreUser HasRole error", zap.Error(err), zap.Int64("user_id", adminUser.UserID))
+		return common.DatabaseErr.WithErr(err)
+	}
+	if !isSuper {
+		return common.PermissionErr
+	}
+
+	// 2. 查询已删除的目标管理员
+	user, err := s.adminUser.GetDeletedUser(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return common.UserNotFoundErr
+		}
+		logger.Error("RestoreUser GetDeletedUser error", zap.Error(err), zap.Any("req", req))
+		return common.DatabaseErr.WithErr(err)
+	}
+
+	// 3. 手机号唯一性校验
+	if errno := s.checkMobileUnique(ctx, user.Mobile, user.ID); !errno.IsOk() {
+		return errno
+	}
+
+	// 4. 取消删除标记
+	err = s.adminUser.RestoreUser(ctx, &do.RestoreUser{
+		AdminUserID: adminUser.UserID, // 记录更新人ID
+		ID:          req.ID,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return common.MobileExistErr // 并发写入时由唯一索引兜底
+		}
+		logger.Error("RestoreUser error", zap.Error(err), zap.Any("req", req))
+		return common.DatabaseErr.WithErr(err)
+	}
+	return common.OK
+}
+
+// checkMobileUnique 校验手机号未被其他未删除的管理员使用
+// 参数:
+//   - ctx: 上下文
+//   - mobile: 手机号,为空时不校验
+//   - excludeID: 排除的管理员ID,0表示不排除
+// 返回: 错误码,已被使用返回MobileExistErr
+// 规则: 已删除的管理员不占用手机号
+func (s *Service) checkMobileUnique(ctx context.Context, mobile string, excludeID int64) common.Errno {
+	if mobile == "" {
+		return common.OK
+	}
+	user, err := s.adminUser.GetUserByMobile(ctx, mobile)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return common.OK
+		}
+		logger.Error("checkMobileUnique GetUserByMobile error", zap.Error(err), zap.String("mobile", mobile))
+		return common.DatabaseErr.WithErr(err)
+	}
+	if user.ID != excludeID {
+		return common.MobileExistErr
+	}
+	return common.OK
+}
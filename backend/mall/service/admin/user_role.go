@@ -0,0 +1,122 @@
+// Package admin 管理员业务逻辑层-管理员角色分配
+// 职责: 查询和整体替换管理员的角色
+// 规则:
+//   - 授予或收回超级管理员角色需要操作人是超级管理员
+//   - 修改自己的角色需要操作人是超级管理员,避免普通管理员给自己授权
+//   - 不能收回最后一个启用中超级管理员的超级管理员角色
+package admin
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"mall/adaptor/repo/admin"
+	"mall/common"
+	"mall/consts"
+	"mall/service/do"
+	"mall/service/dto"
+	"mall/utils/logger"
+	"slices"
+)
+
+// GetUserRoles 查询管理员分配的角色
+// 参数:
+//   - ctx: 上下文
+//   - req: 管理员ID请求DTO
+//
+// 返回: 角色ID列表和错误码,包含已禁用的角色
+// 调用链: api.GetUserRoles -> service.GetUserRoles -> repo.ListRoleIDsByUser
+func (s *Service) GetUserRoles(ctx context.Context, req *dto.UserRoleReq) (*dto.UserRoleResp, common.Errno) {
+	if _, err := s.adminUser.GetUserInfo(ctx, req.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.UserNotFoundErr
+		}
+		logger.Error("GetUserRoles GetUserInfo error", zap.Error(err), zap.Int64("id", req.ID))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	ids, err := s.adminUserRole.ListRoleIDsByUser(ctx, req.ID)
+	if err != nil {
+		logger.Error("GetUserRoles ListRoleIDsByUser error", zap.Error(err), zap.Int64("id", req.ID))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	return &dto.UserRoleResp{
+		ID:      req.ID,
+		RoleIDs: ids,
+	}, common.OK
+}
+
+// UpdateUserRoles 整体替换管理员的角色
+// 参数:
+//   - ctx: 上下文
+//   - adminUser: 当前操作的管理员
+//   - req: 管理员角色请求DTO,RoleIDs为管理员最终拥有的全部角色
+//
+// 返回: 错误码
+// 业务流程:
+//  1. 校验目标管理员存在
+//  2. 校验角色全部存在
+//  3. 超级管理员角色有变化或修改自己的角色时,校验操作人是超级管理员
+//  4. 在一个事务中按差异删除、新增关联,收回超级管理员角色时锁定启用中的超级管理员,保留最后一个,清空权限缓存
+//
+// 调用链: api.UpdateUserRoles -> service.UpdateUserRoles -> repo.ReplaceUserRoles
+func (s *Service) UpdateUserRoles(ctx context.Context, adminUser *common.AdminUser, req *dto.UpdateUserRoleReq) common.Errno {
+	// 1. 查询目标管理员
+	_, err := s.adminUser.GetUserInfo(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return common.UserNotFoundErr
+		}
+		logger.Error("UpdateUserRoles GetUserInfo error", zap.Error(err), zap.Any("req", req))
+		return common.DatabaseErr.WithErr(err)
+	}
+
+	// 2. 校验角色存在
+	roleIDs := uniqueIDs(req.RoleIDs)
+	roles, err := s.role.ListRolesByIDs(ctx, roleIDs)
+	if err != nil {
+		logger.Error("UpdateUserRoles ListRolesByIDs error", zap.Error(err), zap.Any("req", req))
+		return common.DatabaseErr.WithErr(err)
+	}
+	if len(roles) != len(roleIDs) {
+		return common.DataNotFoundErr.WithMsg("角色不存在")
+	}
+
+	// 3. 超级管理员角色变化及修改自己角色校验
+	current, err := s.adminUserRole.ListRoleIDsByUser(ctx, req.ID)
+	if err != nil {
+		logger.Error("UpdateUserRoles ListRoleIDsByUser error", zap.Error(err), zap.Any("req", req))
+		return common.DatabaseErr.WithErr(err)
+	}
+	wasSuper := slices.Contains(current, consts.SuperAdminRoleID)
+	isSuper := slices.Contains(roleIDs, consts.SuperAdminRoleID)
+	if wasSuper != isSuper || req.ID == adminUser.UserID {
+		operatorSuper, err := s.adminUserRole.HasRole(ctx, adminUser.UserID, consts.SuperAdminRoleID)
+		if err != nil {
+			logger.Error("UpdateUserRoles HasRole error", zap.Error(err), zap.Int64("user_id", adminUser.UserID))
+			return common.DatabaseErr.WithErr(err)
+		}
+		if !operatorSuper {
+			return common.PermissionErr
+		}
+	}
+
+	// 4. 替换关联
+	err = s.adminUserRole.ReplaceUserRoles(ctx, &do.ReplaceUserRole{
+		AdminUserID: adminUser.UserID, // 记录操作人ID
+		UserID:      req.ID,
+		RoleIDs:     roleIDs,
+	})
+	if err != nil {
+		if errors.Is(err, admin.ErrLastSuperAdmin) {
+			return common.LastSuperAdminErr
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return common.DataNotFoundErr.WithMsg("角色不存在") // 角色已被并发删除
+		}
+		logger.Error("UpdateUserRoles ReplaceUserRoles error", zap.Error(err), zap.Any("req", req))
+		return common.DatabaseErr.WithErr(err)
+	}
+	s.clearPermCache(ctx)
+	return common.OK
+}
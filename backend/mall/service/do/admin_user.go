@@ -1,5 +1,7 @@
 package do
 
+import "time"
+
 type CreateUser struct {
 	AdminUserID int64  `json:"admin_user_id"`
 	Name        string `json:"name"`
@@ -23,6 +25,36 @@ type UpdateUserStatus struct {
 }
 
 type UpdateUserPassword struct {
-	ID       int64  `json:"id"`
-	Password string `json:"password"`
+	AdminUserID int64  `json:"admin_user_id"`
+	ID          int64  `json:"id"`
+	Password    string `json:"password"`
+}
+
+type ListUser struct {
+	Offset      int       `json:"offset"`
+	Limit       int       `json:"limit"`
+	Name        string    `json:"name"` // 姓名或昵称,模糊匹配
+	Mobile      string    `json:"mobile"`
+	Status      int32     `json:"status"`       // 0表示不过滤
+	Sex         int32     `json:"sex"`          // 0表示不过滤
+	CreateStart time.Time `json:"create_start"` // 零值表示不过滤
+	CreateEnd   time.Time `json:"create_end"`   // 零值表示不过滤
+	OrderBy     string    `json:"order_by"`     // create_at / update_at
+	Desc        bool      `json:"desc"`
+}
+
+type DeleteUser struct {
+	AdminUserID int64 `json:"admin_user_id"`
+	ID          int64 `json:"id"`
+}
+
+type RestoreUser struct {
+	AdminUserID int64 `json:"admin_user_id"`
+	ID          int64 `json:"id"`
+}
+
+type ReplaceUserRole struct {
+	AdminUserID int64   `json:"admin_user_id"`
+	UserID      int64   `json:"user_id"`
+	RoleIDs     []int64 `json:"role_ids"` // 管理员最终拥有的全部角色
 }
@@ -0,0 +1,101 @@
+package do
+
+import (
+	"mall/adaptor/repo/model"
+	"time"
+)
+
+type ListGoods struct {
+	Offset      int    `json:"offset"`
+	Limit       int    `json:"limit"`
+	Name        string `json:"name"`         // 名称模糊匹配
+	SaleType    int32  `json:"sale_type"`    // 0表示不过滤
+	ServiceTime int32  `json:"service_time"` // 0表示不过滤
+	Status      int32  `json:"status"`       // 0表示不过滤
+}
+
+type CreateGoods struct {
+	AdminUserID    int64  `json:"admin_user_id"`
+	Name           string `json:"name"`
+	CoverKey       string `json:"cover_key"`
+	DetailCoverKey string `json:"detail_cover_key"`
+	Detail         string `json:"detail"`
+	CoursePrice    int64  `json:"course_price"`
+	ServiceTime    int32  `json:"service_time"`
+	SaleType       int32  `json:"sale_type"`
+	Features       string `json:"features"`
+}
+
+type UpdateGoods struct {
+	AdminUserID    int64  `json:"admin_user_id"`
+	ID             int64  `json:"id"`
+	Name           string `json:"name"`
+	CoverKey       string `json:"cover_key"`
+	DetailCoverKey string `json:"detail_cover_key"`
+	Detail         string `json:"detail"`
+	CoursePrice    int64  `json:"course_price"`
+	ServiceTime    int32  `json:"service_time"`
+	SaleType       int32  `json:"sale_type"`
+	Features       string `json:"features"`
+	PriceChanged   bool   `json:"price_changed"` // 价格有变化时记录价格历史
+}
+
+type UpdateGoodsStatus struct {
+	AdminUserID int64 `json:"admin_user_id"`
+	ID          int64 `json:"id"`
+	FromStatus  int32 `json:"from_status"` // 变更前状态,状态已被并发修改时不再更新
+	Status      int32 `json:"status"`
+	// Check 上架时根据事务内加锁读取的商品及其目录、课时数量校验上架条件,返回错误时事务回滚,下架时为nil
+	Check func(g *model.CourseGood, catalogs, lessons int64) error `json:"-"`
+}
+
+type CreateCatalog struct {
+	AdminUserID int64  `json:"admin_user_id"`
+	GoodsID     int64  `json:"goods_id"`
+	ParentID    int64  `json:"parent_id"` // 顶级为-1,层级由repo根据加锁读取的父级计算
+	Name        string `json:"name"`
+	Sort        int64  `json:"sort"`
+}
+
+type DeleteCatalog struct {
+	GoodsID int64 `json:"goods_id"`
+	ID      int64 `json:"id"`
+}
+
+type RenameCatalog struct {
+	AdminUserID int64  `json:"admin_user_id"`
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+}
+
+type CatalogPosition struct {
+	ID       int64 `json:"id"`
+	ParentID int64 `json:"parent_id"`
+	Level    int32 `json:"level"` // 根据父级重新计算后的层级
+	Sort     int64 `json:"sort"`
+}
+
+type MoveCatalogs struct {
+	AdminUserID int64 `json:"admin_user_id"`
+	GoodsID     int64 `json:"goods_id"`
+	// Plan 根据事务内加锁读取的全部目录计算位置有变化的节点,返回错误时事务回滚
+	Plan func(catalogs []*model.CourseCatalog) ([]*CatalogPosition, error) `json:"-"`
+}
+
+type SearchGoods struct {
+	Offset   int       `json:"offset"`
+	Limit    int       `json:"limit"`
+	Keyword  string    `json:"keyword"`   // 名称、特色、详情模糊匹配
+	SaleType int32     `json:"sale_type"` // 0表示不过滤
+	MinPrice int64     `json:"min_price"` // 单位分,0表示不限,按Now生效的价格过滤
+	MaxPrice int64     `json:"max_price"` // 单位分,0表示不限,按Now生效的价格过滤
+	Now      time.Time `json:"now"`       // 计价时间
+}
+
+type CreateGoodsPrice struct {
+	AdminUserID   int64      `json:"admin_user_id"`
+	GoodsID       int64      `json:"goods_id"`
+	Price         int64      `json:"price"` // 单位分
+	EffectiveFrom time.Time  `json:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to"` // nil表示长期有效
+}
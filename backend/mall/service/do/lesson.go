@@ -0,0 +1,42 @@
+package do
+
+import "time"
+
+type CreateLesson struct {
+	AdminUserID int64     `json:"admin_user_id"`
+	GoodsID     int64     `json:"goods_id"`
+	CatalogID   int64     `json:"catalog_id"`
+	LessonID    int64     `json:"lesson_id"`
+	EnableTrial int32     `json:"enable_trial"`
+	Sort        int32     `json:"sort"`
+	ShowTime    time.Time `json:"show_time"`
+}
+
+type DeleteLesson struct {
+	GoodsID int64 `json:"goods_id"`
+	ID      int64 `json:"id"`
+}
+
+type LessonPosition struct {
+	ID        int64 `json:"id"`
+	CatalogID int64 `json:"catalog_id"`
+	Sort      int32 `json:"sort"`
+}
+
+type SortLessons struct {
+	AdminUserID int64             `json:"admin_user_id"`
+	GoodsID     int64             `json:"goods_id"`
+	Positions   []*LessonPosition `json:"positions"`
+}
+
+type UpdateLessonTrial struct {
+	AdminUserID int64 `json:"admin_user_id"`
+	ID          int64 `json:"id"`
+	EnableTrial int32 `json:"enable_trial"`
+}
+
+type UpdateLessonShowTime struct {
+	AdminUserID int64     `json:"admin_user_id"`
+	ID          int64     `json:"id"`
+	ShowTime    time.Time `json:"show_time"`
+}
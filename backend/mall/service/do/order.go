@@ -0,0 +1,42 @@
+package do
+
+import "mall/adaptor/repo/model"
+
+type CreateOrder struct {
+	UserID            int64              `json:"user_id"`
+	OrderNo           string             `json:"order_no"`
+	OrderAmount       int64              `json:"order_amount"`        // 单位分,按下单时生效的价格计算
+	OrderOriginAmount int64              `json:"order_origin_amount"` // 单位分,按商品标价计算
+	OrderDesc         string             `json:"order_desc"`
+	UserRemark        string             `json:"user_remark"`
+	AutoPay           bool               `json:"auto_pay"` // 订单金额为0时为true,下单即完成支付并发放课程
+	Items             []*CreateOrderItem `json:"items"`
+}
+
+type CreateOrderItem struct {
+	GoodsID           int64  `json:"goods_id"`
+	GoodsType         int32  `json:"goods_type"`
+	GoodsSnap         string `json:"goods_snap"`          // 商品快照JSON
+	ServiceExpireTime int64  `json:"service_expire_time"` // 辅导到期时间,毫秒时间戳,随订单商品保存,支付后写入已购课程
+}
+
+type GoodsSnap struct {
+	*model.CourseGood
+	PayPrice int64 `json:"pay_price"` // 下单时生效的价格,单位分
+}
+
+type TransitOrder struct {
+	OrderID       int64  `json:"order_id"`
+	UserID        int64  `json:"user_id"`     // 大于0时校验订单归属,用户操作时传入
+	FromStatus    int32  `json:"from_status"` // 由状态机按订单当前状态填写
+	ToStatus      int32  `json:"to_status"`
+	OperatorType  int32  `json:"operator_type"`
+	OperatorID    int64  `json:"operator_id"`
+	Remark        string `json:"remark"`         // 流转记录备注,取消时同时作为取消原因
+	PaymentAmount int64  `json:"payment_amount"` // 流转到已支付时写入,单位分
+	TradeNo       string `json:"trade_no"`       // 流转到已支付时写入
+	CancelType    int32  `json:"cancel_type"`    // 流转到已取消时写入
+	RefundAmount  int64  `json:"refund_amount"`  // 流转到已退款时写入,单位分
+	ConfirmType   int32  `json:"confirm_type"`   // 流转到已收货时写入
+	TransitAt     int64  `json:"transit_at"`     // 变更时间,毫秒时间戳,由状态机填写
+}
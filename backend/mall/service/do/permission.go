@@ -0,0 +1,30 @@
+package do
+
+type CreatePermission struct {
+	AdminUserID int64  `json:"admin_user_id"`
+	Code        string `json:"code"`
+	Type        int32  `json:"type"`
+	Name        string `json:"name"`
+	PagePath    string `json:"page_path"`
+	ParentID    int64  `json:"parent_id"`
+	Sort        int32  `json:"sort"`
+	Desc        string `json:"desc"`
+}
+
+type UpdatePermission struct {
+	AdminUserID int64  `json:"admin_user_id"`
+	ID          int64  `json:"id"`
+	Code        string `json:"code"`
+	Type        int32  `json:"type"`
+	Name        string `json:"name"`
+	PagePath    string `json:"page_path"`
+	ParentID    int64  `json:"parent_id"`
+	Sort        int32  `json:"sort"`
+	Desc        string `json:"desc"`
+}
+
+type UpdatePermissionStatus struct {
+	AdminUserID int64   `json:"admin_user_id"`
+	IDs         []int64 `json:"ids"` // 禁用时包含全部子孙节点
+	Status      int32   `json:"status"`
+}
@@ -0,0 +1,33 @@
+package do
+
+type ListRole struct {
+	Offset int    `json:"offset"`
+	Limit  int    `json:"limit"`
+	Name   string `json:"name"`   // 名称模糊匹配
+	Status int32  `json:"status"` // 0表示不过滤
+}
+
+type CreateRole struct {
+	AdminUserID int64  `json:"admin_user_id"`
+	Name        string `json:"name"`
+	Desc        string `json:"desc"`
+}
+
+type UpdateRole struct {
+	AdminUserID int64  `json:"admin_user_id"`
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Desc        string `json:"desc"`
+}
+
+type UpdateRoleStatus struct {
+	AdminUserID int64 `json:"admin_user_id"`
+	ID          int64 `json:"id"`
+	Status      int32 `json:"status"`
+}
+
+type ReplaceRolePermission struct {
+	AdminUserID   int64   `json:"admin_user_id"`
+	RoleID        int64   `json:"role_id"`
+	PermissionIDs []int64 `json:"permission_ids"` // 角色最终拥有的全部权限
+}
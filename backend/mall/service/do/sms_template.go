@@ -0,0 +1,32 @@
+package do
+
+type ListSmsTemplate struct {
+	SceneCode string `json:"scene_code"`
+	Platform  string `json:"platform"`
+	Status    int32  `json:"status"` // 0表示不过滤
+}
+
+type CreateSmsTemplate struct {
+	AdminUserID    int64  `json:"admin_user_id"`
+	SceneCode      string `json:"scene_code"`
+	SignName       string `json:"sign_name"`
+	PlatformTmplID int32  `json:"platform_tmpl_id"`
+	TmplStr        string `json:"tmpl_str"`
+	Platform       string `json:"platform"`
+}
+
+type UpdateSmsTemplate struct {
+	AdminUserID    int64  `json:"admin_user_id"`
+	ID             int64  `json:"id"`
+	SceneCode      string `json:"scene_code"`
+	SignName       string `json:"sign_name"`
+	PlatformTmplID int32  `json:"platform_tmpl_id"`
+	TmplStr        string `json:"tmpl_str"`
+	Platform       string `json:"platform"`
+}
+
+type UpdateSmsTemplateStatus struct {
+	AdminUserID int64 `json:"admin_user_id"`
+	ID          int64 `json:"id"`
+	Status      int32 `json:"status"`
+}
@@ -0,0 +1,6 @@
+package do
+
+type UpdateCustomerPassword struct {
+	ID       int64  `json:"id"`
+	Password string `json:"password"`
+}
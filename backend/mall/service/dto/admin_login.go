@@ -36,3 +36,45 @@ type CheckCaptchaDtoResp struct {
 	Ticket string `json:"ticket"`
 	Expire int64  `json:"expire"`
 }
+
+type MobilePasswordLoginReq struct {
+	Mobile   string `json:"mobile"`
+	Password string `json:"password"`
+	Ticket   string `json:"ticket"` // 滑块校验通过后获得的一次性凭证
+	ClientIP string `json:"-"`      // 客户端IP,由API层填充
+}
+
+type AdminLoginResp struct {
+	Token       string `json:"token"`
+	Expire      int64  `json:"expire"` // Token有效期(秒)
+	UserID      int64  `json:"user_id"`
+	Name        string `json:"name"`
+	LastLoginAt int64  `json:"last_login_at"` // 上次登录时间,毫秒时间戳,首次登录为0
+	LastLoginIP string `json:"last_login_ip"` // 上次登录IP
+}
+
+type SendSmsCodeReq struct {
+	Mobile string `json:"mobile"`
+	Ticket string `json:"ticket"` // 滑块校验通过后获得的一次性凭证
+}
+
+type SendSmsCodeResp struct {
+	Expire int64 `json:"expire"` // 验证码有效期(秒)
+}
+
+type MobileVerifyLoginReq struct {
+	Mobile   string `json:"mobile"`
+	SmsCode  string `json:"sms_code"`
+	ClientIP string `json:"-"` // 客户端IP,由API层填充
+}
+
+type ResetPasswordReq struct {
+	Mobile   string `json:"mobile"`
+	SmsCode  string `json:"sms_code"`
+	Password string `json:"password"` // 新密码
+}
+
+type ChangePasswordReq struct {
+	OldPassword string `json:"old_password"`
+	NewPassword string `json:"new_password"`
+}
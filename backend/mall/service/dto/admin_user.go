@@ -23,3 +23,44 @@ type UpdateUserStatusReq struct {
 	ID     int64 `json:"id"`
 	Status int32 `json:"status"`
 }
+
+type ListUserReq struct {
+	PageReq
+	Name        string `form:"name"` // 姓名或昵称,模糊匹配
+	Mobile      string `form:"mobile"`
+	Status      int32  `form:"status"`       // 1：正常 -1：禁用 不传表示全部
+	Sex         int32  `form:"sex"`          // 1：男 2：女 3：其他 不传表示全部
+	CreateStart int64  `form:"create_start"` // 创建时间起,毫秒时间戳
+	CreateEnd   int64  `form:"create_end"`   // 创建时间止,毫秒时间戳
+	OrderBy     string `form:"order_by"`     // 排序字段: create_at(默认) / update_at
+	Order       string `form:"order"`        // 排序方向: desc(默认) / asc
+}
+
+type UserItem struct {
+	UserID   int64  `json:"user_id"`
+	Name     string `json:"name"`
+	NickName string `json:"nick_name"`
+	Mobile   string `json:"mobile"`
+	Sex      int32  `json:"sex"`
+	Status   int32  `json:"status"`
+	CreateAt int64  `json:"create_at"` // 毫秒时间戳
+	UpdateAt int64  `json:"update_at"` // 毫秒时间戳
+}
+
+type UserIDReq struct {
+	ID int64 `json:"id"`
+}
+
+type UserRoleReq struct {
+	ID int64 `form:"id"` // 管理员ID
+}
+
+type UserRoleResp struct {
+	ID      int64   `json:"id"`
+	RoleIDs []int64 `json:"role_ids"`
+}
+
+type UpdateUserRoleReq struct {
+	ID      int64   `json:"id"`
+	RoleIDs []int64 `json:"role_ids"` // 管理员最终拥有的全部角色,空数组表示清空
+}
@@ -0,0 +1,201 @@
+package dto
+
+import "mall/adaptor/repo/model"
+
+type ListGoodsReq struct {
+	PageReq
+	Name        string `form:"name"`         // 名称模糊匹配
+	SaleType    int32  `form:"sale_type"`    // 1：免费 2：收费 不传表示全部
+	ServiceTime int32  `form:"service_time"` // 1：一个月 2：三个月 3：半年 4：一年 不传表示全部
+	Status      int32  `form:"status"`       // 1：上架 -1：下架 不传表示全部
+}
+
+type GoodsItem struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	CoverKey    string `json:"cover_key"`
+	CoursePrice int64  `json:"course_price"` // 单位分
+	ServiceTime int32  `json:"service_time"`
+	SaleType    int32  `json:"sale_type"`
+	Status      int32  `json:"status"`
+	CreateBy    int64  `json:"create_by"`
+	UpdateBy    int64  `json:"update_by"`
+	CreateAt    int64  `json:"create_at"` // 毫秒时间戳
+	UpdateAt    int64  `json:"update_at"` // 毫秒时间戳
+}
+
+type GoodsDetail struct {
+	ID             int64  `json:"id"`
+	Name           string `json:"name"`
+	CoverKey       string `json:"cover_key"`
+	DetailCoverKey string `json:"detail_cover_key"`
+	Detail         string `json:"detail"`
+	CoursePrice    int64  `json:"course_price"` // 单位分
+	ServiceTime    int32  `json:"service_time"`
+	SaleType       int32  `json:"sale_type"`
+	Status         int32  `json:"status"`
+	Features       string `json:"features"`
+	CreateBy       int64  `json:"create_by"`
+	UpdateBy       int64  `json:"update_by"`
+	CreateAt       int64  `json:"create_at"` // 毫秒时间戳
+	UpdateAt       int64  `json:"update_at"` // 毫秒时间戳
+}
+
+type CreateGoodsReq struct {
+	Name           string `json:"name"`
+	CoverKey       string `json:"cover_key"`
+	DetailCoverKey string `json:"detail_cover_key"`
+	Detail         string `json:"detail"`
+	CoursePrice    int64  `json:"course_price"` // 单位分,免费商品必须为0
+	ServiceTime    int32  `json:"service_time"` // 1：一个月 2：三个月 3：半年 4：一年
+	SaleType       int32  `json:"sale_type"`    // 1：免费 2：收费
+	Features       string `json:"features"`
+}
+
+type UpdateGoodsReq struct {
+	ID             int64  `json:"id"`
+	Name           string `json:"name"`
+	CoverKey       string `json:"cover_key"`
+	DetailCoverKey string `json:"detail_cover_key"`
+	Detail         string `json:"detail"`
+	CoursePrice    int64  `json:"course_price"`
+	ServiceTime    int32  `json:"service_time"`
+	SaleType       int32  `json:"sale_type"`
+	Features       string `json:"features"`
+}
+
+type GoodsIDReq struct {
+	ID int64 `form:"id" json:"id"`
+}
+
+type CatalogNode struct {
+	ID       int64          `json:"id"`
+	ParentID int64          `json:"parent_id"` // -1表示顶级
+	Level    int32          `json:"level"`     // 顶级为1
+	Name     string         `json:"name"`
+	Sort     int64          `json:"sort"`
+	UpdateAt int64          `json:"update_at"` // 毫秒时间戳
+	Children []*CatalogNode `json:"children"`
+}
+
+// NewCatalogTree 将目录列表组装为树
+// 参数: catalogs 目录列表,需已按Sort、ID升序
+// 返回: 顶级节点列表,父级不在列表中的节点作为顶级节点返回
+func NewCatalogTree(catalogs []*model.CourseCatalog) []*CatalogNode {
+	nodes := make(map[int64]*CatalogNode, len(catalogs))
+	for _, catalog := range catalogs {
+		nodes[catalog.ID] = &CatalogNode{
+			ID:       catalog.ID,
+			ParentID: catalog.ParentID,
+			Level:    catalog.Level,
+			Name:     catalog.Name,
+			Sort:     catalog.Sort,
+			UpdateAt: catalog.UpdateAt.UnixMilli(),
+			Children: []*CatalogNode{},
+		}
+	}
+
+	roots := make([]*CatalogNode, 0)
+	for _, catalog := range catalogs {
+		node := nodes[catalog.ID]
+		if parent, ok := nodes[catalog.ParentID]; ok && catalog.ParentID != catalog.ID {
+			parent.Children = append(parent.Children, node)
+			continue
+		}
+		roots = append(roots, node)
+	}
+	return roots
+}
+
+type CatalogTreeReq struct {
+	GoodsID int64 `form:"goods_id"`
+}
+
+type CreateCatalogReq struct {
+	GoodsID  int64  `json:"goods_id"`
+	ParentID int64  `json:"parent_id"` // 不传或-1表示顶级
+	Name     string `json:"name"`
+	Sort     int64  `json:"sort"`
+}
+
+type RenameCatalogReq struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type CatalogIDReq struct {
+	ID int64 `json:"id"`
+}
+
+type MoveCatalogItem struct {
+	ID       int64 `json:"id"`
+	ParentID int64 `json:"parent_id"` // 不传或-1表示移动到顶级
+	Sort     int64 `json:"sort"`
+}
+
+type MoveCatalogReq struct {
+	GoodsID int64              `json:"goods_id"`
+	Items   []*MoveCatalogItem `json:"items"` // 拖拽后位置变化的节点,一次提交
+}
+
+type SearchGoodsReq struct {
+	PageReq
+	Keyword  string `form:"keyword"`   // 名称、特色、详情模糊匹配
+	SaleType int32  `form:"sale_type"` // 1：免费 2：收费 不传表示全部
+	MinPrice int64  `form:"min_price"` // 单位分,不传表示不限
+	MaxPrice int64  `form:"max_price"` // 单位分,不传表示不限
+}
+
+type CourseGoodsItem struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	CoverKey    string `json:"cover_key"`
+	CoursePrice int64  `json:"course_price"` // 单位分
+	ServiceTime int32  `json:"service_time"`
+	SaleType    int32  `json:"sale_type"`
+	Features    string `json:"features"`
+}
+
+type CourseGoodsDetail struct {
+	ID             int64               `json:"id"`
+	Name           string              `json:"name"`
+	CoverKey       string              `json:"cover_key"`
+	DetailCoverKey string              `json:"detail_cover_key"`
+	Detail         string              `json:"detail"`
+	CoursePrice    int64               `json:"course_price"` // 单位分
+	ServiceTime    int32               `json:"service_time"`
+	SaleType       int32               `json:"sale_type"`
+	Features       string              `json:"features"`
+	Catalogs       []*CatalogNode      `json:"catalogs"`      // 目录树
+	TrialLessons   []*CourseLessonItem `json:"trial_lessons"` // 已可见的试听课时
+}
+
+type GoodsPriceReq struct {
+	GoodsID int64 `form:"goods_id"`
+}
+
+type GoodsPriceItem struct {
+	ID            int64 `json:"id"`
+	Price         int64 `json:"price"`          // 单位分
+	EffectiveFrom int64 `json:"effective_from"` // 毫秒时间戳
+	EffectiveTo   int64 `json:"effective_to"`   // 毫秒时间戳,0表示长期有效
+	Current       bool  `json:"current"`        // 是否为当前生效的价格
+	CreateBy      int64 `json:"create_by"`
+	CreateAt      int64 `json:"create_at"` // 毫秒时间戳
+}
+
+type GoodsPriceResp struct {
+	CurrentPrice int64             `json:"current_price"` // 当前价格,单位分
+	List         []*GoodsPriceItem `json:"list"`
+}
+
+type ScheduleGoodsPriceReq struct {
+	GoodsID       int64 `json:"goods_id"`
+	Price         int64 `json:"price"`          // 单位分
+	EffectiveFrom int64 `json:"effective_from"` // 毫秒时间戳,必须晚于当前时间
+	EffectiveTo   int64 `json:"effective_to"`   // 毫秒时间戳,不传表示长期有效
+}
+
+type GoodsPriceIDReq struct {
+	ID int64 `json:"id"`
+}
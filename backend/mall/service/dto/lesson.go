@@ -0,0 +1,58 @@
+package dto
+
+type LessonItem struct {
+	ID          int64 `json:"id"`
+	CatalogID   int64 `json:"catalog_id"`
+	LessonID    int64 `json:"lesson_id"`    // 录播课时ID
+	EnableTrial int32 `json:"enable_trial"` // 1：可试听 -1：不可试听
+	Sort        int32 `json:"sort"`
+	ShowTime    int64 `json:"show_time"` // 毫秒时间戳
+	UpdateBy    int64 `json:"update_by"`
+	UpdateAt    int64 `json:"update_at"` // 毫秒时间戳
+}
+
+type ListLessonReq struct {
+	GoodsID int64 `form:"goods_id"`
+}
+
+type AttachLessonReq struct {
+	GoodsID     int64 `json:"goods_id"`
+	CatalogID   int64 `json:"catalog_id"`
+	LessonID    int64 `json:"lesson_id"`    // 录播课时ID
+	EnableTrial int32 `json:"enable_trial"` // 1：可试听 其他值表示不可试听
+	Sort        int32 `json:"sort"`
+	ShowTime    int64 `json:"show_time"` // 毫秒时间戳,不传表示立即可见
+}
+
+type LessonIDReq struct {
+	ID int64 `json:"id"`
+}
+
+type SortLessonItem struct {
+	ID        int64 `json:"id"`
+	CatalogID int64 `json:"catalog_id"` // 可移动到同一课程的其他目录下
+	Sort      int32 `json:"sort"`
+}
+
+type SortLessonReq struct {
+	GoodsID int64             `json:"goods_id"`
+	Items   []*SortLessonItem `json:"items"`
+}
+
+type ScheduleLessonReq struct {
+	ID       int64 `json:"id"`
+	ShowTime int64 `json:"show_time"` // 毫秒时间戳
+}
+
+type CourseLessonReq struct {
+	GoodsID int64 `form:"goods_id"`
+}
+
+type CourseLessonItem struct {
+	ID        int64 `json:"id"`
+	CatalogID int64 `json:"catalog_id"`
+	LessonID  int64 `json:"lesson_id"` // 录播课时ID
+	Sort      int32 `json:"sort"`
+	Trial     bool  `json:"trial"`     // 是否可试听
+	ShowTime  int64 `json:"show_time"` // 毫秒时间戳
+}
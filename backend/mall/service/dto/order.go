@@ -0,0 +1,24 @@
+package dto
+
+type PlaceOrderReq struct {
+	GoodsIDs   []int64 `json:"goods_ids"`
+	UserRemark string  `json:"user_remark"`
+}
+
+type PlaceOrderResp struct {
+	OrderID           int64  `json:"order_id"`
+	OrderNo           string `json:"order_no"`
+	OrderAmount       int64  `json:"order_amount"`        // 单位分
+	OrderOriginAmount int64  `json:"order_origin_amount"` // 单位分
+	Status            int32  `json:"status"`              // 订单状态,0元订单下单即为已支付
+	PayDeadline       int64  `json:"pay_deadline"`        // 支付截止时间,毫秒时间戳,超时未支付自动取消,已支付为0
+}
+
+type OrderIDReq struct {
+	ID int64 `json:"id"`
+}
+
+type CancelOrderReq struct {
+	ID     int64  `json:"id"`
+	Reason string `json:"reason"` // 取消原因,最多255个字符
+}
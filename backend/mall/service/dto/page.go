@@ -0,0 +1,51 @@
+package dto
+
+const (
+	defaultPageSize = 20  // 默认每页条数
+	maxPageSize     = 100 // 每页条数上限
+)
+
+// PageReq 分页请求,列表查询请求内嵌使用
+type PageReq struct {
+	Page     int `form:"page" json:"page"`           // 页码,从1开始
+	PageSize int `form:"page_size" json:"page_size"` // 每页条数,默认20,最大100
+}
+
+// Normalize 修正非法的页码和每页条数
+func (r *PageReq) Normalize() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+	if r.PageSize < 1 {
+		r.PageSize = defaultPageSize
+	}
+	if r.PageSize > maxPageSize {
+		r.PageSize = maxPageSize
+	}
+}
+
+// Offset 计算查询偏移量,调用前需先Normalize
+func (r *PageReq) Offset() int {
+	return (r.Page - 1) * r.PageSize
+}
+
+// PageResp 分页响应
+type PageResp[T any] struct {
+	List     []T   `json:"list"`
+	Total    int64 `json:"total"` // 总条数
+	Page     int   `json:"page"`
+	PageSize int   `json:"page_size"`
+}
+
+// NewPageResp 根据分页请求构造分页响应
+func NewPageResp[T any](req *PageReq, list []T, total int64) *PageResp[T] {
+	if list == nil {
+		list = []T{}
+	}
+	return &PageResp[T]{
+		List:     list,
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+}
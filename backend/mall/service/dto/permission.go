@@ -0,0 +1,56 @@
+package dto
+
+type PermNode struct {
+	ID       int64       `json:"id"`
+	Code     string      `json:"code"`
+	Type     int32       `json:"type"` // 1：菜单 2：操作
+	Name     string      `json:"name"`
+	PagePath string      `json:"page_path"`
+	ParentID int64       `json:"parent_id"` // -1表示顶级
+	Status   int32       `json:"status"`
+	Sort     int32       `json:"sort"`
+	Desc     string      `json:"desc"`
+	UpdateAt int64       `json:"update_at"` // 毫秒时间戳
+	Children []*PermNode `json:"children"`
+}
+
+type CreatePermissionReq struct {
+	Code     string `json:"code"`
+	Type     int32  `json:"type"` // 1：菜单 2：操作
+	Name     string `json:"name"`
+	PagePath string `json:"page_path"`
+	ParentID int64  `json:"parent_id"` // 不传或-1表示顶级
+	Sort     int32  `json:"sort"`
+	Desc     string `json:"desc"`
+}
+
+type UpdatePermissionReq struct {
+	ID       int64  `json:"id"`
+	Code     string `json:"code"`
+	Type     int32  `json:"type"`
+	Name     string `json:"name"`
+	PagePath string `json:"page_path"`
+	ParentID int64  `json:"parent_id"` // 修改即移动节点
+	Sort     int32  `json:"sort"`
+	Desc     string `json:"desc"`
+}
+
+type PermissionIDReq struct {
+	ID int64 `json:"id"`
+}
+
+type MyPermissionResp struct {
+	Codes []string    `json:"codes"` // 有效权限编码(含菜单和操作)
+	Menus []*PermNode `json:"menus"` // 有效菜单树,不含操作
+}
+
+type RoutePerm struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Code   string `json:"code"`
+}
+
+type SyncPermissionResp struct {
+	Created  []string `json:"created"`  // 新增的操作权限编码
+	Orphaned []string `json:"orphaned"` // 没有路由声明的操作权限编码
+}
@@ -0,0 +1,47 @@
+package dto
+
+type ListRoleReq struct {
+	PageReq
+	Name   string `form:"name"`   // 名称模糊匹配
+	Status int32  `form:"status"` // 1：正常 -1：禁用 不传表示全部
+}
+
+type RoleItem struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Desc     string `json:"desc"`
+	Status   int32  `json:"status"`
+	CreateBy int64  `json:"create_by"`
+	UpdateBy int64  `json:"update_by"`
+	CreateAt int64  `json:"create_at"` // 毫秒时间戳
+	UpdateAt int64  `json:"update_at"` // 毫秒时间戳
+}
+
+type CreateRoleReq struct {
+	Name string `json:"name"`
+	Desc string `json:"desc"`
+}
+
+type UpdateRoleReq struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Desc string `json:"desc"`
+}
+
+type RoleIDReq struct {
+	ID int64 `json:"id"`
+}
+
+type RolePermissionReq struct {
+	ID int64 `form:"id"` // 角色ID
+}
+
+type RolePermissionResp struct {
+	ID            int64   `json:"id"`
+	PermissionIDs []int64 `json:"permission_ids"`
+}
+
+type UpdateRolePermissionReq struct {
+	ID            int64   `json:"id"`
+	PermissionIDs []int64 `json:"permission_ids"` // 角色最终拥有的全部权限,空数组表示清空
+}
@@ -0,0 +1,51 @@
+package dto
+
+type ListSmsTemplateReq struct {
+	SceneCode string `form:"scene_code"`
+	Platform  string `form:"platform"`
+	Status    int32  `form:"status"` // 1：正常 -1：禁用 不传表示全部
+}
+
+type SmsTemplateItem struct {
+	ID             int64  `json:"id"`
+	SceneCode      string `json:"scene_code"`
+	SignName       string `json:"sign_name"`
+	PlatformTmplID int32  `json:"platform_tmpl_id"`
+	TmplStr        string `json:"tmpl_str"`
+	Platform       string `json:"platform"`
+	Status         int32  `json:"status"`
+	AdminUserID    int64  `json:"admin_user_id"` // 最后操作人
+	CreateAt       int64  `json:"create_at"`     // 毫秒时间戳
+	UpdateAt       int64  `json:"update_at"`     // 毫秒时间戳
+}
+
+type CreateSmsTemplateReq struct {
+	SceneCode      string `json:"scene_code"`
+	SignName       string `json:"sign_name"`
+	PlatformTmplID int32  `json:"platform_tmpl_id"`
+	TmplStr        string `json:"tmpl_str"` // 模板内容,参数占位符格式{1}、{2}
+	Platform       string `json:"platform"` // tencent, ronglian, fake
+}
+
+type UpdateSmsTemplateReq struct {
+	ID             int64  `json:"id"`
+	SceneCode      string `json:"scene_code"`
+	SignName       string `json:"sign_name"`
+	PlatformTmplID int32  `json:"platform_tmpl_id"`
+	TmplStr        string `json:"tmpl_str"`
+	Platform       string `json:"platform"`
+}
+
+type SmsTemplateIDReq struct {
+	ID int64 `json:"id"`
+}
+
+type SendTestSmsReq struct {
+	ID     int64    `json:"id"`
+	Mobile string   `json:"mobile"`
+	Params []string `json:"params"` // 示例参数,按占位符序号排列
+}
+
+type SendTestSmsResp struct {
+	Content string `json:"content"` // 渲染后的短信内容
+}
@@ -0,0 +1,22 @@
+package dto
+
+type CustomerUserInfoResp struct {
+	UserID   int64  `json:"user_id"`
+	NickName string `json:"nick_name"`
+	Sex      int32  `json:"sex"`      // 0：其他 1：男 2：女
+	IconKey  string `json:"icon_key"` // 头像云存储key
+}
+
+type CustomerPasswordLoginReq struct {
+	Mobile   string `json:"mobile"`
+	Password string `json:"password"`
+	Ticket   string `json:"ticket"` // 滑块校验通过后获得的一次性凭证
+	ClientIP string `json:"-"`      // 客户端IP,由API层填充
+}
+
+type CustomerLoginResp struct {
+	Token    string `json:"token"`
+	Expire   int64  `json:"expire"` // Token有效期(秒)
+	UserID   int64  `json:"user_id"`
+	NickName string `json:"nick_name"`
+}
@@ -0,0 +1,165 @@
+// Package orderstate 订单状态机
+// 职责: 集中定义订单状态的合法流转,前台、管理后台和后台任务统一通过Transit变更订单状态
+// 状态流转:
+//
+//	待支付(1) -> 已支付(2) / 已取消(-1)
+//	已支付(2) -> 已发货(4) / 已退款(3)
+//	已发货(4) -> 已签收(5) / 已收货(6)
+//	已签收(5) -> 已收货(6)
+//
+// 规则:
+//   - 已取消、已退款、已收货为终态,不能再流转
+//   - 按原状态乐观更新,并发回调中只有一个能变更成功
+//   - 重复的支付回调只有交易号和支付金额一致时才视为幂等,否则返回OrderTradeErr
+//   - 每次变更写入一条order_status_log流转记录
+//   - 流转到已支付时在同一事务中按订单商品写入user_course_goods,买家获得课程
+package orderstate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"mall/adaptor"
+	"mall/adaptor/repo/model"
+	"mall/adaptor/repo/order"
+	"mall/common"
+	"mall/consts"
+	"mall/service/do"
+	"mall/utils/logger"
+	"time"
+	"unicode/utf8"
+)
+
+// transitions 订单状态的合法流转,key为当前状态,value为可流转到的状态
+var transitions = map[int32][]int32{
+	consts.OrderStatusPending: {consts.OrderStatusPaid, consts.OrderStatusCancelled},
+	consts.OrderStatusPaid:    {consts.OrderStatusShipped, consts.OrderStatusRefunded},
+	consts.OrderStatusShipped: {consts.OrderStatusSigned, consts.OrderStatusReceived},
+	consts.OrderStatusSigned:  {consts.OrderStatusReceived},
+}
+
+// MaxRemarkLen 流转记录备注的最大字符数,与order_status_log.remark、orders.cancel_reason的长度一致
+const MaxRemarkLen = 255
+
+// CanTransit 判断订单状态能否从from流转到to
+func CanTransit(from, to int32) bool {
+	for _, status := range transitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Machine 订单状态机
+type Machine struct {
+	order order.IOrder // 订单数据访问接口
+}
+
+// NewMachine 创建订单状态机实例
+// 参数: adaptor 适配器,提供数据库连接
+// 返回: Machine实例
+// 调用链: service.NewService -> NewMachine
+func NewMachine(adaptor adaptor.IAdaptor) *Machine {
+	return &Machine{
+		order: order.NewOrder(adaptor),
+	}
+}
+
+// Transit 变更订单状态
+// 参数:
+//   - ctx: 上下文
+//   - req: 状态变更请求DO,FromStatus和TransitAt由状态机填写
+//
+// 返回: 错误码
+// 业务流程:
+//  1. 校验备注长度,查询订单,指定UserID时校验订单归属
+//  2. 订单已是目标状态时,与本次请求一致则直接返回成功(重复回调幂等)
+//  3. 校验流转是否合法,不合法返回OrderStatusErr
+//  4. 按原状态乐观更新,同时写入流转记录,流转到已支付时发放课程
+//  5. 更新失败说明订单被并发修改,重新查询后按步骤2判断,否则返回OrderChangedErr
+//
+// 调用链: service -> orderstate.Transit -> repo.TransitOrder
+func (m *Machine) Transit(ctx context.Context, req *do.TransitOrder) common.Errno {
+	// 1. 查询订单
+	if utf8.RuneCountInString(req.Remark) > MaxRemarkLen {
+		return common.ParamErr.WithMsg(fmt.Sprintf("备注最多%d个字符", MaxRemarkLen))
+	}
+	o, errno := m.getOrder(ctx, req.OrderID)
+	if !errno.IsOk() {
+		return errno
+	}
+	if req.UserID > 0 && o.UserID != req.UserID {
+		return common.DataNotFoundErr
+	}
+
+	// 2. 已是目标状态
+	if o.Status == req.ToStatus {
+		return sameTransit(o, req)
+	}
+
+	// 3. 校验流转
+	if !CanTransit(o.Status, req.ToStatus) {
+		return common.OrderStatusErr
+	}
+
+	// 4. 乐观更新
+	req.FromStatus = o.Status
+	req.TransitAt = time.Now().UnixMilli()
+	ok, err := m.order.TransitOrder(ctx, req)
+	if err != nil {
+		logger.Error("Transit TransitOrder error", zap.Error(err), zap.Any("req", req))
+		return common.DatabaseErr.WithErr(err)
+	}
+	if ok {
+		logger.Info("Transit", zap.Int64("order_id", req.OrderID), zap.Int32("from", req.FromStatus), zap.Int32("to", req.ToStatus),
+			zap.Int32("operator_type", req.OperatorType), zap.Int64("operator_id", req.OperatorID))
+		return common.OK
+	}
+
+	// 5. 并发修改
+	o, errno = m.getOrder(ctx, req.OrderID)
+	if !errno.IsOk() {
+		return errno
+	}
+	if o.Status == req.ToStatus {
+		return sameTransit(o, req)
+	}
+	logger.Warn("Transit order changed", zap.Int64("order_id", req.OrderID), zap.Int32("from", req.FromStatus), zap.Int32("to", req.ToStatus),
+		zap.Int32("current", o.Status))
+	return common.OrderChangedErr
+}
+
+// sameTransit 判断已处于目标状态的订单是否由相同的请求变更
+// 参数:
+//   - o: 已处于目标状态的订单
+//   - req: 本次状态变更请求
+//
+// 返回: 错误码,支付回调的交易号或支付金额与订单不一致返回OrderTradeErr(可能重复支付,需人工处理)
+func sameTransit(o *model.Order, req *do.TransitOrder) common.Errno {
+	if req.ToStatus != consts.OrderStatusPaid {
+		return common.OK
+	}
+	if o.TradeNo != req.TradeNo || o.PaymentAmount != req.PaymentAmount {
+		logger.Error("Transit paid by another trade", zap.Int64("order_id", o.ID), zap.String("trade_no", o.TradeNo),
+			zap.String("req_trade_no", req.TradeNo), zap.Int64("payment_amount", o.PaymentAmount), zap.Int64("req_payment_amount", req.PaymentAmount))
+		return common.OrderTradeErr
+	}
+	return common.OK
+}
+
+// getOrder 根据ID获取订单
+// 返回: 订单对象和错误码,不存在返回DataNotFoundErr
+func (m *Machine) getOrder(ctx context.Context, id int64) (*model.Order, common.Errno) {
+	o, err := m.order.GetOrder(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.DataNotFoundErr
+		}
+		logger.Error("getOrder GetOrder error", zap.Error(err), zap.Int64("id", id))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	return o, common.OK
+}
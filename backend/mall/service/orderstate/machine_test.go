@@ -0,0 +1,182 @@
+package orderstate
+
+import (
+	"context"
+	"mall/adaptor/repo/model"
+	"mall/adaptor/repo/order"
+	"mall/common"
+	"mall/consts"
+	"mall/service/do"
+	"strings"
+	"testing"
+)
+
+// allStatuses 全部订单状态
+var allStatuses = []int32{
+	consts.OrderStatusCancelled,
+	consts.OrderStatusPending,
+	consts.OrderStatusPaid,
+	consts.OrderStatusRefunded,
+	consts.OrderStatusShipped,
+	consts.OrderStatusSigned,
+	consts.OrderStatusReceived,
+}
+
+func TestCanTransit(t *testing.T) {
+	allowed := map[[2]int32]bool{
+		{consts.OrderStatusPending, consts.OrderStatusPaid}:      true,
+		{consts.OrderStatusPending, consts.OrderStatusCancelled}: true,
+		{consts.OrderStatusPaid, consts.OrderStatusShipped}:      true,
+		{consts.OrderStatusPaid, consts.OrderStatusRefunded}:     true,
+		{consts.OrderStatusShipped, consts.OrderStatusSigned}:    true,
+		{consts.OrderStatusShipped, consts.OrderStatusReceived}:  true,
+		{consts.OrderStatusSigned, consts.OrderStatusReceived}:   true,
+	}
+	for _, from := range allStatuses {
+		for _, to := range allStatuses {
+			if got, want := CanTransit(from, to), allowed[[2]int32{from, to}]; got != want {
+				t.Errorf("CanTransit(%d, %d) = %v, want %v", from, to, got, want)
+			}
+		}
+	}
+	if CanTransit(0, consts.OrderStatusPending) || CanTransit(consts.OrderStatusPending, 0) {
+		t.Error("CanTransit accepted an unknown status")
+	}
+}
+
+func TestTransitionsTable(t *testing.T) {
+	terminal := []int32{consts.OrderStatusCancelled, consts.OrderStatusRefunded, consts.OrderStatusReceived}
+	for _, status := range terminal {
+		if len(transitions[status]) > 0 {
+			t.Errorf("terminal status %d has transitions %v", status, transitions[status])
+		}
+	}
+	known := make(map[int32]bool, len(allStatuses))
+	for _, status := range allStatuses {
+		known[status] = true
+	}
+	for from, tos := range transitions {
+		if !known[from] {
+			t.Errorf("unknown from status %d", from)
+		}
+		for _, to := range tos {
+			if !known[to] || to == from {
+				t.Errorf("invalid transition %d -> %d", from, to)
+			}
+		}
+	}
+}
+
+// fakeOrder 内存中的订单数据访问实现
+type fakeOrder struct {
+	order    *model.Order
+	items    []*model.OrderItem
+	owned    []*model.UserCourseGood
+	transits []*do.TransitOrder
+}
+
+func (f *fakeOrder) CreateOrder(ctx context.Context, req *do.CreateOrder) (int64, error) {
+	return 0, nil
+}
+
+func (f *fakeOrder) GetOrder(ctx context.Context, id int64) (*model.Order, error) {
+	o := *f.order
+	return &o, nil
+}
+
+func (f *fakeOrder) ListTimeoutOrders(ctx context.Context, createBefore int64, limit int) ([]*model.Order, error) {
+	return nil, nil
+}
+
+func (f *fakeOrder) TransitOrder(ctx context.Context, req *do.TransitOrder) (bool, error) {
+	if f.order.Status != req.FromStatus {
+		return false, nil
+	}
+	f.transits = append(f.transits, req)
+	f.order.Status = req.ToStatus
+	if req.ToStatus == consts.OrderStatusPaid {
+		f.order.TradeNo = req.TradeNo
+		f.order.PaymentAmount = req.PaymentAmount
+		f.owned = append(f.owned, order.OwnedGoods(f.items, req.TransitAt)...)
+	}
+	return true, nil
+}
+
+func TestTransit(t *testing.T) {
+	paid := &do.TransitOrder{OrderID: 1, ToStatus: consts.OrderStatusPaid, TradeNo: "T1", PaymentAmount: 100}
+	cases := []struct {
+		name     string
+		order    model.Order
+		req      do.TransitOrder
+		want     common.Errno
+		transits int
+	}{
+		{"pay pending", model.Order{ID: 1, UserID: 7, Status: consts.OrderStatusPending}, *paid, common.OK, 1},
+		{"repeat paid same trade", model.Order{ID: 1, Status: consts.OrderStatusPaid, TradeNo: "T1", PaymentAmount: 100}, *paid, common.OK, 0},
+		{"repeat paid other trade", model.Order{ID: 1, Status: consts.OrderStatusPaid, TradeNo: "T2", PaymentAmount: 100}, *paid, common.OrderTradeErr, 0},
+		{"repeat paid other amount", model.Order{ID: 1, Status: consts.OrderStatusPaid, TradeNo: "T1", PaymentAmount: 90}, *paid, common.OrderTradeErr, 0},
+		{"pay cancelled", model.Order{ID: 1, Status: consts.OrderStatusCancelled}, *paid, common.OrderStatusErr, 0},
+		{"repeat cancel", model.Order{ID: 1, UserID: 7, Status: consts.OrderStatusCancelled},
+			do.TransitOrder{OrderID: 1, UserID: 7, ToStatus: consts.OrderStatusCancelled}, common.OK, 0},
+		{"cancel other user", model.Order{ID: 1, UserID: 8, Status: consts.OrderStatusPending},
+			do.TransitOrder{OrderID: 1, UserID: 7, ToStatus: consts.OrderStatusCancelled}, common.DataNotFoundErr, 0},
+		{"cancel reason too long", model.Order{ID: 1, UserID: 7, Status: consts.OrderStatusPending},
+			do.TransitOrder{OrderID: 1, UserID: 7, ToStatus: consts.OrderStatusCancelled, Remark: strings.Repeat("取", MaxRemarkLen+1)}, common.ParamErr, 0},
+		{"cancel reason max length", model.Order{ID: 1, UserID: 7, Status: consts.OrderStatusPending},
+			do.TransitOrder{OrderID: 1, UserID: 7, ToStatus: consts.OrderStatusCancelled, Remark: strings.Repeat("取", MaxRemarkLen)}, common.OK, 1},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			repo := &fakeOrder{order: &c.order}
+			m := &Machine{order: repo}
+			req := c.req
+			got := m.Transit(context.Background(), &req)
+			if got.Code != c.want.Code {
+				t.Errorf("Transit() = %d %s, want %d", got.Code, got.Msg, c.want.Code)
+			}
+			if len(repo.transits) != c.transits {
+				t.Errorf("TransitOrder called %d times, want %d", len(repo.transits), c.transits)
+			}
+		})
+	}
+}
+
+func TestTransitGrantsGoods(t *testing.T) {
+	repo := &fakeOrder{
+		order: &model.Order{ID: 1, UserID: 7, Status: consts.OrderStatusPending},
+		items: []*model.OrderItem{
+			{ID: 11, OrderID: 1, UserID: 7, GoodsID: 101, GoodsType: consts.GoodsTypeCourse, ServiceExpireTime: 1000},
+			{ID: 12, OrderID: 1, UserID: 7, GoodsID: 102, GoodsType: consts.GoodsTypeCourse, ServiceExpireTime: 2000},
+		},
+	}
+	m := &Machine{order: repo}
+	paid := do.TransitOrder{OrderID: 1, ToStatus: consts.OrderStatusPaid, TradeNo: "T1", PaymentAmount: 100}
+	for i := 0; i < 2; i++ {
+		req := paid
+		if got := m.Transit(context.Background(), &req); got.Code != common.OK.Code {
+			t.Fatalf("Transit() = %d %s, want OK", got.Code, got.Msg)
+		}
+	}
+	if len(repo.owned) != len(repo.items) {
+		t.Fatalf("granted %d goods, want %d", len(repo.owned), len(repo.items))
+	}
+	for i, owned := range repo.owned {
+		item := repo.items[i]
+		if owned.UserID != 7 || owned.OrderID != 1 || owned.GoodsID != item.GoodsID || owned.GoodsType != item.GoodsType ||
+			owned.ServiceExpireTime != item.ServiceExpireTime || owned.BuyTime != repo.transits[0].TransitAt {
+			t.Errorf("granted %+v for item %+v", owned, item)
+		}
+	}
+
+	cancelled := &fakeOrder{
+		order: &model.Order{ID: 1, UserID: 7, Status: consts.OrderStatusPending},
+		items: repo.items,
+	}
+	m = &Machine{order: cancelled}
+	if got := m.Transit(context.Background(), &do.TransitOrder{OrderID: 1, ToStatus: consts.OrderStatusCancelled}); got.Code != common.OK.Code {
+		t.Fatalf("Transit() = %d %s, want OK", got.Code, got.Msg)
+	}
+	if len(cancelled.owned) != 0 {
+		t.Errorf("cancelled order granted %d goods", len(cancelled.owned))
+	}
+}
@@ -0,0 +1,200 @@
+// Package user 前台用户业务逻辑层-课程
+// 职责: 用户端课程列表、搜索、详情和课时查询
+// 规则:
+//   - 只展示上架中的课程商品,下架或不存在的商品统一返回DataNotFoundErr
+//   - ShowTime晚于当前时间的课时不返回
+package user
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"mall/adaptor/repo/model"
+	"mall/common"
+	"mall/consts"
+	"mall/service/do"
+	"mall/service/dto"
+	"mall/utils/logger"
+	"time"
+)
+
+// SearchGoods 分页查询上架中的课程商品
+// 参数:
+//   - ctx: 上下文
+//   - req: 查询条件DTO(含分页参数)
+//
+// 返回: 分页响应和错误码
+// 过滤: 关键字匹配名称、特色或详情,免费/收费,价格区间(单位分,按当前生效的价格过滤)
+// 价格: 返回当前生效的价格,与过滤使用同一计价时间
+// 调用链: api/customer.SearchGoods -> service.SearchGoods -> repo.SearchGoods
+func (s *Service) SearchGoods(ctx context.Context, req *dto.SearchGoodsReq) (*dto.PageResp[*dto.CourseGoodsItem], common.Errno) {
+	req.Normalize()
+	switch req.SaleType {
+	case 0, consts.SaleTypeFree, consts.SaleTypePaid:
+	default:
+		return nil, common.ParamErr.WithMsg("售卖类型只能是免费或收费")
+	}
+	if req.MinPrice < 0 || req.MaxPrice < 0 {
+		return nil, common.ParamErr.WithMsg("价格不能为负数")
+	}
+	if req.MaxPrice > 0 && req.MinPrice > req.MaxPrice {
+		return nil, common.ParamErr.WithMsg("最低价格不能高于最高价格")
+	}
+
+	timeNow := time.Now()
+	goods, total, err := s.goods.SearchGoods(ctx, &do.SearchGoods{
+		Offset:   req.Offset(),
+		Limit:    req.PageSize,
+		Keyword:  req.Keyword,
+		SaleType: req.SaleType,
+		MinPrice: req.MinPrice,
+		MaxPrice: req.MaxPrice,
+		Now:      timeNow,
+	})
+	if err != nil {
+		logger.Error("SearchGoods error", zap.Error(err), zap.Any("req", req))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	prices, errno := s.resolveGoodsPrices(ctx, goods, timeNow)
+	if !errno.IsOk() {
+		return nil, errno
+	}
+	items := make([]*dto.CourseGoodsItem, 0, len(goods))
+	for _, g := range goods {
+		items = append(items, &dto.CourseGoodsItem{
+			ID:          g.ID,
+			Name:        g.Name,
+			CoverKey:    g.CoverKey,
+			CoursePrice: prices[g.ID], // 当前生效的价格
+			ServiceTime: g.ServiceTime,
+			SaleType:    g.SaleType,
+			Features:    g.Features,
+		})
+	}
+	return dto.NewPageResp(&req.PageReq, items, total), common.OK
+}
+
+// GetCourseDetail 获取课程详情
+// 参数:
+//   - ctx: 上下文
+//   - req: 商品ID请求DTO
+//
+// 返回: 商品信息、目录树和试听课时,错误码
+// 业务流程:
+//  1. 校验课程商品存在且已上架
+//  2. 查询目录树
+//  3. 查询已可见的试听课时
+//  4. 解析当前生效的价格
+//
+// 调用链: api/customer.GetCourseDetail -> service.GetCourseDetail -> repo.GetGoods/ListCatalogs/ListVisibleLessons
+func (s *Service) GetCourseDetail(ctx context.Context, req *dto.GoodsIDReq) (*dto.CourseGoodsDetail, common.Errno) {
+	// 1. 校验课程商品
+	g, errno := s.getOnShelfGoods(ctx, req.ID)
+	if !errno.IsOk() {
+		return nil, errno
+	}
+
+	// 2. 查询目录树
+	catalogs, err := s.catalog.ListCatalogs(ctx, g.ID)
+	if err != nil {
+		logger.Error("GetCourseDetail ListCatalogs error", zap.Error(err), zap.Int64("goods_id", g.ID))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+
+	// 3. 查询试听课时
+	now := time.Now()
+	lessons, err := s.lesson.ListVisibleLessons(ctx, g.ID, now)
+	if err != nil {
+		logger.Error("GetCourseDetail ListVisibleLessons error", zap.Error(err), zap.Int64("goods_id", g.ID))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	trialLessons := make([]*dto.CourseLessonItem, 0)
+	for _, lesson := range lessons {
+		if lesson.EnableTrial != consts.LessonTrialOn {
+			continue
+		}
+		trialLessons = append(trialLessons, &dto.CourseLessonItem{
+			ID:        lesson.ID,
+			CatalogID: lesson.CatalogID,
+			LessonID:  lesson.LessonID,
+			Sort:      lesson.Sort,
+			Trial:     true,
+			ShowTime:  lesson.ShowTime.UnixMilli(),
+		})
+	}
+
+	// 4. 解析当前价格
+	price, errno := s.resolveGoodsPrice(ctx, g, now)
+	if !errno.IsOk() {
+		return nil, errno
+	}
+
+	return &dto.CourseGoodsDetail{
+		ID:             g.ID,
+		Name:           g.Name,
+		CoverKey:       g.CoverKey,
+		DetailCoverKey: g.DetailCoverKey,
+		Detail:         g.Detail,
+		CoursePrice:    price, // 当前生效的价格
+		ServiceTime:    g.ServiceTime,
+		SaleType:       g.SaleType,
+		Features:       g.Features,
+		Catalogs:       dto.NewCatalogTree(catalogs),
+		TrialLessons:   trialLessons,
+	}, common.OK
+}
+
+// ListCourseLessons 获取课程的可见课时列表
+// 参数:
+//   - ctx: 上下文
+//   - req: 课时列表请求DTO
+//
+// 返回: 课时列表和错误码
+// 业务流程:
+//  1. 校验课程商品存在且已上架
+//  2. 查询已到可见时间的课时,标记可试听的课时
+//
+// 调用链: api/customer.ListCourseLessons -> service.ListCourseLessons -> repo.ListVisibleLessons
+func (s *Service) ListCourseLessons(ctx context.Context, req *dto.CourseLessonReq) ([]*dto.CourseLessonItem, common.Errno) {
+	// 1. 校验课程商品
+	if _, errno := s.getOnShelfGoods(ctx, req.GoodsID); !errno.IsOk() {
+		return nil, errno
+	}
+
+	// 2. 查询可见课时
+	lessons, err := s.lesson.ListVisibleLessons(ctx, req.GoodsID, time.Now())
+	if err != nil {
+		logger.Error("ListCourseLessons ListVisibleLessons error", zap.Error(err), zap.Int64("goods_id", req.GoodsID))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	items := make([]*dto.CourseLessonItem, 0, len(lessons))
+	for _, lesson := range lessons {
+		items = append(items, &dto.CourseLessonItem{
+			ID:        lesson.ID,
+			CatalogID: lesson.CatalogID,
+			LessonID:  lesson.LessonID,
+			Sort:      lesson.Sort,
+			Trial:     lesson.EnableTrial == consts.LessonTrialOn,
+			ShowTime:  lesson.ShowTime.UnixMilli(),
+		})
+	}
+	return items, common.OK
+}
+
+// getOnShelfGoods 获取上架中的课程商品
+// 返回: 商品对象和错误码,不存在或已下架返回DataNotFoundErr
+func (s *Service) getOnShelfGoods(ctx context.Context, id int64) (*model.CourseGood, common.Errno) {
+	g, err := s.goods.GetGoods(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.DataNotFoundErr
+		}
+		logger.Error("getOnShelfGoods GetGoods error", zap.Error(err), zap.Int64("id", id))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	if g.Status != consts.GoodsOnShelf {
+		return nil, common.DataNotFoundErr
+	}
+	return g, common.OK
+}
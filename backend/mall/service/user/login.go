@@ -0,0 +1,192 @@
+// Package user 前台用户业务逻辑层-登录
+// 职责: 前台用户登录相关业务逻辑
+// 登录方式: 手机号+密码(需先通过滑块验证获取Ticket)
+package user
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"mall/common"
+	"mall/consts"
+	"mall/service/do"
+	"mall/service/dto"
+	"mall/utils/logger"
+	"mall/utils/password"
+	"time"
+)
+
+// 登录失败次数限制,按手机号和客户端IP分别计数,窗口内每次失败重新计时
+const (
+	loginFailScene        = "customer_password" // 登录失败计数场景
+	loginFailWindow       = 15 * time.Minute    // 失败计数窗口
+	loginFailMaxPerMobile = 5                   // 单个手机号窗口内最大失败次数
+	loginFailMaxPerIP     = 20                  // 单个IP窗口内最大失败次数
+)
+
+// MobilePasswordLogin 手机号+密码登录
+// 参数:
+//   - ctx: 上下文
+//   - req: 登录请求DTO(手机号、密码、滑块Ticket、客户端IP)
+//
+// 返回: 登录响应DTO(Token及用户信息)和错误码
+// 业务流程:
+//  1. 校验手机号和IP的失败次数是否超限
+//  2. 校验滑块Ticket(一次性,获取后即删除)
+//  3. 根据手机号查询用户,不存在时仍执行一次哈希校验,避免通过响应时间枚举手机号
+//  4. 校验密码哈希,失败时累加手机号和IP的失败次数
+//  5. 校验账号状态,历史哈希升级为当前默认算法,清空手机号失败次数
+//  6. 签发Token
+//
+// 错误码: 失败次数超限-LoginLimitErr Ticket过期-TicketExpiredErr 密码错误-WrongPasswordErr 账号禁用-UserDisabledErr
+// 调用链: api/customer.MobilePasswordLogin -> service.MobilePasswordLogin
+func (s *Service) MobilePasswordLogin(ctx context.Context, req *dto.CustomerPasswordLoginReq) (*dto.CustomerLoginResp, common.Errno) {
+	if req.Mobile == "" || req.Password == "" || req.Ticket == "" {
+		return nil, common.ParamErr.WithMsg("手机号、密码和滑块凭证不能为空")
+	}
+
+	// 1. 校验失败次数
+	if errno := s.checkLoginLimit(ctx, req.Mobile, req.ClientIP); !errno.IsOk() {
+		return nil, errno
+	}
+
+	// 2. 校验滑块Ticket
+	if errno := s.checkTicket(ctx, req.Ticket); !errno.IsOk() {
+		return nil, errno
+	}
+
+	// 3. 根据手机号查询用户(不存在时与密码错误返回相同错误码,避免手机号被枚举)
+	user, err := s.user.GetUserByMobile(ctx, req.Mobile)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			password.Verify("", req.Password)
+			s.recordLoginFailure(ctx, req.Mobile, req.ClientIP)
+			return nil, common.WrongPasswordErr
+		}
+		logger.Error("MobilePasswordLogin GetUserByMobile error", zap.Error(err))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+
+	// 4. 校验密码(未设置密码的用户只能使用其他方式登录)
+	ok, needRehash := password.Verify(user.Password, req.Password)
+	if !ok {
+		s.recordLoginFailure(ctx, req.Mobile, req.ClientIP)
+		return nil, common.WrongPasswordErr
+	}
+
+	// 5. 校验账号状态
+	if user.Status == consts.IsDisable {
+		return nil, common.UserDisabledErr
+	}
+	if needRehash {
+		s.upgradePassword(ctx, user.ID, req.Password)
+	}
+	if err = s.frequency.ClearLoginFailures(ctx, loginFailScene, "mobile:"+req.Mobile); err != nil {
+		logger.Warn("MobilePasswordLogin ClearLoginFailures error", zap.Error(err), zap.Int64("user_id", user.ID))
+	}
+
+	// 6. 签发Token
+	tokenStr, expire, errno := s.genToken(user)
+	if !errno.IsOk() {
+		return nil, errno
+	}
+	return &dto.CustomerLoginResp{
+		Token:    tokenStr,
+		Expire:   expire,
+		UserID:   user.ID,
+		NickName: user.NickName,
+	}, common.OK
+}
+
+// checkLoginLimit 校验手机号和IP的登录失败次数
+// 参数:
+//   - ctx: 上下文
+//   - mobile: 手机号
+//   - clientIP: 客户端IP,为空时不按IP限制
+//
+// 返回: 错误码,任一维度超限返回LoginLimitErr
+func (s *Service) checkLoginLimit(ctx context.Context, mobile, clientIP string) common.Errno {
+	count, err := s.frequency.GetLoginFailures(ctx, loginFailScene, "mobile:"+mobile)
+	if err != nil {
+		logger.Error("checkLoginLimit GetLoginFailures error", zap.Error(err))
+		return common.RedisErr.WithErr(err)
+	}
+	if count >= loginFailMaxPerMobile {
+		return common.LoginLimitErr
+	}
+	if clientIP == "" {
+		return common.OK
+	}
+	count, err = s.frequency.GetLoginFailures(ctx, loginFailScene, "ip:"+clientIP)
+	if err != nil {
+		logger.Error("checkLoginLimit GetLoginFailures error", zap.Error(err))
+		return common.RedisErr.WithErr(err)
+	}
+	if count >= loginFailMaxPerIP {
+		return common.LoginLimitErr
+	}
+	return common.OK
+}
+
+// recordLoginFailure 累加手机号和IP的登录失败次数
+// 参数:
+//   - ctx: 上下文
+//   - mobile: 手机号
+//   - clientIP: 客户端IP,为空时只按手机号计数
+//
+// 特性: 计数失败只记录日志,不改变本次登录结果
+func (s *Service) recordLoginFailure(ctx context.Context, mobile, clientIP string) {
+	if _, err := s.frequency.IncrLoginFailure(ctx, loginFailScene, "mobile:"+mobile, loginFailWindow); err != nil {
+		logger.Warn("recordLoginFailure IncrLoginFailure error", zap.Error(err))
+	}
+	if clientIP == "" {
+		return
+	}
+	if _, err := s.frequency.IncrLoginFailure(ctx, loginFailScene, "ip:"+clientIP, loginFailWindow); err != nil {
+		logger.Warn("recordLoginFailure IncrLoginFailure error", zap.Error(err), zap.String("client_ip", clientIP))
+	}
+}
+
+// checkTicket 校验滑块Ticket
+// 参数:
+//   - ctx: 上下文
+//   - ticket: 滑块校验通过后获得的凭证
+//
+// 返回: 错误码,Ticket不存在或已过期返回TicketExpiredErr
+// 特性: Ticket获取后即删除,只能使用一次
+func (s *Service) checkTicket(ctx context.Context, ticket string) common.Errno {
+	captchaKey, err := s.verify.GetCaptchaTicket(ctx, ticket)
+	if err != nil {
+		logger.Error("checkTicket GetCaptchaTicket error", zap.Error(err))
+		return common.RedisErr.WithErr(err)
+	}
+	if captchaKey == "" {
+		return common.TicketExpiredErr
+	}
+	return common.OK
+}
+
+// upgradePassword 登录成功后将历史哈希升级为当前默认算法
+// 参数:
+//   - ctx: 上下文
+//   - userID: 用户ID
+//   - plain: 本次登录校验通过的密码明文
+//
+// 特性: 升级失败只记录日志,不影响登录,下次登录会再次尝试
+func (s *Service) upgradePassword(ctx context.Context, userID int64, plain string) {
+	hashed, err := password.Hash(plain)
+	if err != nil {
+		logger.Warn("upgradePassword Hash error", zap.Error(err), zap.Int64("user_id", userID))
+		return
+	}
+	err = s.user.UpdateUserPassword(ctx, &do.UpdateCustomerPassword{
+		ID:       userID,
+		Password: hashed,
+	})
+	if err != nil {
+		logger.Warn("upgradePassword UpdateUserPassword error", zap.Error(err), zap.Int64("user_id", userID))
+		return
+	}
+	logger.Info("upgradePassword success", zap.Int64("user_id", userID))
+}
@@ -0,0 +1,212 @@
+// Package user 前台用户业务逻辑层-订单
+// 职责: 用户下单、取消订单和确认收货
+// 规则:
+//   - 商品必须存在且已上架,已购买过(含已支付的订单)或已有待支付订单的课程不能重复下单
+//   - 0元订单(免费课程)下单即完成支付并发放课程,不进入超时取消
+//   - 订单金额按下单时生效的价格计算,原价按商品标价计算,单位分
+//   - 每个订单商品保存下单时的商品快照,后续改价或修改商品信息不影响已有订单
+//   - 取消和确认收货通过订单状态机变更,只能操作自己的订单
+//   - 下单后需在支付截止时间前支付,超时未支付的订单由RunOrderTimeout自动取消
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.uber.org/zap"
+	"mall/adaptor/repo/model"
+	"mall/adaptor/repo/order"
+	"mall/common"
+	"mall/consts"
+	"mall/service/do"
+	"mall/service/dto"
+	"mall/utils/logger"
+	"mall/utils/tools"
+	"time"
+)
+
+// PlaceOrder 用户下单
+// 参数:
+//   - ctx: 上下文
+//   - user: 当前登录用户
+//   - req: 下单请求DTO
+//
+// 返回: 订单ID、订单号、金额、订单状态和支付截止时间,错误码
+// 业务流程:
+//  1. 商品ID去重,校验数量
+//  2. 校验商品存在且已上架
+//  3. 按当前生效的价格计算金额,生成商品快照
+//  4. 生成订单号,在同一事务中校验未购买过且没有待支付订单,写入订单和订单商品,
+//     0元订单同时完成支付并发放课程
+//  5. 待支付订单记录支付截止时间,供超时自动取消任务扫描
+//
+// 错误码: 已购买-GoodsOwnedErr 已有待支付订单-OrderPendingErr
+// 调用链: api/customer.PlaceOrder -> service.PlaceOrder -> repo.CreateOrder
+func (s *Service) PlaceOrder(ctx context.Context, user *common.User, req *dto.PlaceOrderReq) (*dto.PlaceOrderResp, common.Errno) {
+	// 1. 商品ID去重
+	goodsIDs := make([]int64, 0, len(req.GoodsIDs))
+	seen := make(map[int64]bool, len(req.GoodsIDs))
+	for _, id := range req.GoodsIDs {
+		if id <= 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		goodsIDs = append(goodsIDs, id)
+	}
+	if len(goodsIDs) == 0 {
+		return nil, common.ParamErr.WithMsg("请选择要购买的课程")
+	}
+	if len(goodsIDs) > consts.MaxOrderGoods {
+		return nil, common.ParamErr.WithMsg(fmt.Sprintf("单次最多购买%d个课程", consts.MaxOrderGoods))
+	}
+
+	// 2. 校验商品已上架
+	goods, err := s.goods.ListGoodsByIDs(ctx, goodsIDs)
+	if err != nil {
+		logger.Error("PlaceOrder ListGoodsByIDs error", zap.Error(err), zap.Int64s("goods_ids", goodsIDs))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	goodsMap := make(map[int64]*model.CourseGood, len(goods))
+	for _, g := range goods {
+		goodsMap[g.ID] = g
+	}
+	for _, id := range goodsIDs {
+		g, ok := goodsMap[id]
+		if !ok || g.Status != consts.GoodsOnShelf {
+			return nil, common.GoodsOffShelfErr
+		}
+	}
+
+	// 3. 计算金额,生成商品快照
+	timeNow := time.Now()
+	prices, errno := s.resolveGoodsPrices(ctx, goods, timeNow)
+	if !errno.IsOk() {
+		return nil, errno
+	}
+	newOrder := &do.CreateOrder{
+		UserID:     user.UserID,
+		UserRemark: req.UserRemark,
+		Items:      make([]*do.CreateOrderItem, 0, len(goodsIDs)),
+	}
+	for _, id := range goodsIDs {
+		g := goodsMap[id]
+		snap, err := json.Marshal(&do.GoodsSnap{
+			CourseGood: g,
+			PayPrice:   prices[id],
+		})
+		if err != nil {
+			logger.Error("PlaceOrder Marshal GoodsSnap error", zap.Error(err), zap.Int64("goods_id", id))
+			return nil, common.ServerErr.WithErr(err)
+		}
+		newOrder.OrderAmount += prices[id]
+		if g.SaleType == consts.SaleTypePaid {
+			newOrder.OrderOriginAmount += g.CoursePrice
+		}
+		newOrder.Items = append(newOrder.Items, &do.CreateOrderItem{
+			GoodsID:           id,
+			GoodsType:         consts.GoodsTypeCourse,
+			GoodsSnap:         string(snap),
+			ServiceExpireTime: serviceExpireAt(timeNow, g.ServiceTime).UnixMilli(),
+		})
+	}
+	newOrder.AutoPay = newOrder.OrderAmount == 0
+	newOrder.OrderDesc = goodsMap[goodsIDs[0]].Name
+	if len(goodsIDs) > 1 {
+		newOrder.OrderDesc = fmt.Sprintf("%s等%d个课程", newOrder.OrderDesc, len(goodsIDs))
+	}
+
+	// 4. 写入订单
+	newOrder.OrderNo = tools.UUIDHex()
+	orderID, err := s.order.CreateOrder(ctx, newOrder)
+	if err != nil {
+		var conflict *order.GoodsConflictError
+		if errors.As(err, &conflict) {
+			if conflict.Pending {
+				return nil, common.OrderPendingErr.WithMsg(goodsMap[conflict.GoodsID].Name)
+			}
+			return nil, common.GoodsOwnedErr.WithMsg(goodsMap[conflict.GoodsID].Name)
+		}
+		logger.Error("PlaceOrder CreateOrder error", zap.Error(err), zap.Int64("user_id", user.UserID), zap.Int64s("goods_ids", goodsIDs))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	logger.Info("PlaceOrder", zap.Int64("order_id", orderID), zap.String("order_no", newOrder.OrderNo), zap.Int64("user_id", user.UserID), zap.Int64("order_amount", newOrder.OrderAmount))
+
+	resp := &dto.PlaceOrderResp{
+		OrderID:           orderID,
+		OrderNo:           newOrder.OrderNo,
+		OrderAmount:       newOrder.OrderAmount,
+		OrderOriginAmount: newOrder.OrderOriginAmount,
+		Status:            consts.OrderStatusPaid,
+	}
+	if newOrder.AutoPay {
+		return resp, common.OK
+	}
+
+	// 5. 记录支付截止时间(订单已创建,记录失败只打日志,不影响用户支付)
+	resp.Status = consts.OrderStatusPending
+	resp.PayDeadline = time.Now().Add(s.payTimeout).UnixMilli()
+	if err = s.orderDeadline.AddOrderDeadline(ctx, orderID, resp.PayDeadline); err != nil {
+		logger.Error("PlaceOrder AddOrderDeadline error", zap.Error(err), zap.Int64("order_id", orderID))
+	}
+	return resp, common.OK
+}
+
+// serviceExpireAt 计算辅导服务到期时间
+// 参数:
+//   - from: 购买时间
+//   - serviceTime: 辅导服务时长(consts.ServiceTimeOneMonth等)
+//
+// 返回: 到期时间,未知的时长视为没有辅导服务,返回购买时间
+func serviceExpireAt(from time.Time, serviceTime int32) time.Time {
+	switch serviceTime {
+	case consts.ServiceTimeOneMonth:
+		return from.AddDate(0, 1, 0)
+	case consts.ServiceTimeThreeMonths:
+		return from.AddDate(0, 3, 0)
+	case consts.ServiceTimeHalfYear:
+		return from.AddDate(0, 6, 0)
+	case consts.ServiceTimeOneYear:
+		return from.AddDate(1, 0, 0)
+	}
+	return from
+}
+
+// CancelOrder 用户取消订单
+// 参数:
+//   - ctx: 上下文
+//   - user: 当前登录用户
+//   - req: 取消订单请求DTO
+//
+// 返回: 错误码,只有待支付的订单可以取消,其他状态返回OrderStatusErr,取消原因超过255个字符返回ParamErr
+// 调用链: api/customer.CancelOrder -> service.CancelOrder -> orderstate.Transit
+func (s *Service) CancelOrder(ctx context.Context, user *common.User, req *dto.CancelOrderReq) common.Errno {
+	return s.orderState.Transit(ctx, &do.TransitOrder{
+		OrderID:      req.ID,
+		UserID:       user.UserID, // 只能取消自己的订单
+		ToStatus:     consts.OrderStatusCancelled,
+		OperatorType: consts.OrderOperatorUser,
+		OperatorID:   user.UserID,
+		Remark:       req.Reason,
+		CancelType:   consts.OrderCancelByUser,
+	})
+}
+
+// ConfirmOrder 用户确认收货
+// 参数:
+//   - ctx: 上下文
+//   - user: 当前登录用户
+//   - req: 订单ID请求DTO
+//
+// 返回: 错误码,只有已发货或已签收的订单可以确认收货,其他状态返回OrderStatusErr
+// 调用链: api/customer.ConfirmOrder -> service.ConfirmOrder -> orderstate.Transit
+func (s *Service) ConfirmOrder(ctx context.Context, user *common.User, req *dto.OrderIDReq) common.Errno {
+	return s.orderState.Transit(ctx, &do.TransitOrder{
+		OrderID:      req.ID,
+		UserID:       user.UserID, // 只能确认自己的订单
+		ToStatus:     consts.OrderStatusReceived,
+		OperatorType: consts.OrderOperatorUser,
+		OperatorID:   user.UserID,
+		ConfirmType:  consts.OrderConfirmByUser,
+	})
+}
@@ -0,0 +1,161 @@
+// Package user 前台用户业务逻辑层-未支付订单自动取消
+// 职责: 定时扫描超过支付截止时间的待支付订单并自动取消
+// 规则:
+//   - 下单时按配置order.pay_timeout记录支付截止时间,到期仍未支付的订单自动取消
+//   - 取消类型为超时取消(CancelType=3),取消人为系统(CancelBy=-1),通过订单状态机变更
+//   - 多实例同时运行时,每个到期订单通过租约只会被一个实例认领处理
+//   - 订单处理完成(取消成功、已支付或已取消)后才移除截止时间;处理失败或实例退出时,租约到期后重新处理
+//   - Redis中的截止时间可能丢失(下单时写入失败、Redis数据丢失),定时扫描数据库补回超时未支付的订单
+//   - 课程商品没有库存,目前也没有优惠券,取消时无需释放;引入后在cancelTimeoutOrder中释放
+package user
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"mall/common"
+	"mall/consts"
+	"mall/service/do"
+	"mall/utils/logger"
+	"time"
+)
+
+const (
+	orderTimeoutInterval      = 5 * time.Second // 扫描间隔
+	orderTimeoutBatch         = 100             // 每次扫描的订单数量上限
+	orderTimeoutLease         = time.Minute     // 认领租约,处理失败或实例退出时租约到期后重新处理
+	orderTimeoutSweepInterval = time.Minute     // 数据库兜底扫描间隔
+	orderTimeoutSweepDelay    = time.Minute     // 数据库兜底扫描只处理超时超过该时间的订单,避免与正常流程重复
+	orderTimeoutSweepBatch    = 500             // 每次数据库兜底扫描的订单数量上限
+)
+
+// RunOrderTimeout 运行未支付订单自动取消任务
+// 参数: ctx 上下文,取消后任务退出
+// 特性:
+//   - 每个扫描间隔处理一次到期订单,一批处理满时立即继续处理下一批
+//   - 启动时及每个兜底扫描间隔扫描一次数据库,补回Redis中缺失的超时订单
+//
+// 调用链: main.main -> router.RunTasks -> api/customer.RunOrderTimeout -> service.RunOrderTimeout
+func (s *Service) RunOrderTimeout(ctx context.Context) {
+	logger.Info("RunOrderTimeout started", zap.Duration("pay_timeout", s.payTimeout))
+	ticker := time.NewTicker(orderTimeoutInterval)
+	defer ticker.Stop()
+	sweepTicker := time.NewTicker(orderTimeoutSweepInterval)
+	defer sweepTicker.Stop()
+	s.SweepTimeoutOrders(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("RunOrderTimeout stopped")
+			return
+		case <-sweepTicker.C:
+			s.SweepTimeoutOrders(ctx)
+		case <-ticker.C:
+			for ctx.Err() == nil {
+				count, errno := s.CancelTimeoutOrders(ctx)
+				if !errno.IsOk() || count < orderTimeoutBatch {
+					break
+				}
+			}
+		}
+	}
+}
+
+// SweepTimeoutOrders 扫描数据库,补回Redis中缺失的超时未支付订单
+// 参数: ctx 上下文
+// 返回: 本次扫描到的订单数量和错误码
+// 业务流程:
+//  1. 查询创建时间早于(当前时间-支付超时-兜底延迟)且仍待支付的订单
+//  2. 订单不在Redis中时按创建时间+支付超时记录截止时间,由CancelTimeoutOrders取消
+//
+// 特性: 已在Redis中(包括正在被认领处理)的订单不做修改
+// 调用链: service.RunOrderTimeout -> SweepTimeoutOrders -> repo.ListTimeoutOrders
+func (s *Service) SweepTimeoutOrders(ctx context.Context) (int, common.Errno) {
+	// 1. 查询超时未支付的订单
+	createBefore := time.Now().Add(-s.payTimeout - orderTimeoutSweepDelay).UnixMilli()
+	orders, err := s.order.ListTimeoutOrders(ctx, createBefore, orderTimeoutSweepBatch)
+	if err != nil {
+		logger.Error("SweepTimeoutOrders ListTimeoutOrders error", zap.Error(err))
+		return 0, common.DatabaseErr.WithErr(err)
+	}
+
+	// 2. 补回截止时间
+	for _, o := range orders {
+		deadline := o.CreateAt + s.payTimeout.Milliseconds()
+		if err = s.orderDeadline.EnsureOrderDeadline(ctx, o.ID, deadline); err != nil {
+			logger.Error("SweepTimeoutOrders EnsureOrderDeadline error", zap.Error(err), zap.Int64("order_id", o.ID))
+			return 0, common.RedisErr.WithErr(err)
+		}
+	}
+	if len(orders) > 0 {
+		logger.Info("SweepTimeoutOrders", zap.Int("count", len(orders)))
+	}
+	return len(orders), common.OK
+}
+
+// CancelTimeoutOrders 取消一批超过支付截止时间的订单
+// 参数: ctx 上下文
+// 返回: 本次扫描到的到期订单数量和错误码
+// 业务流程:
+//  1. 读取支付截止时间已到的订单
+//  2. 逐个认领(截止时间推迟为租约到期时间),被其他实例认领的订单跳过
+//  3. 通过订单状态机取消认领到的订单
+//
+// 调用链: service.RunOrderTimeout -> CancelTimeoutOrders -> orderstate.Transit
+func (s *Service) CancelTimeoutOrders(ctx context.Context) (int, common.Errno) {
+	// 1. 读取到期订单
+	now := time.Now().UnixMilli()
+	orderIDs, err := s.orderDeadline.ListDueOrders(ctx, now, orderTimeoutBatch)
+	if err != nil {
+		logger.Error("CancelTimeoutOrders ListDueOrders error", zap.Error(err))
+		return 0, common.RedisErr.WithErr(err)
+	}
+
+	leaseUntil := now + orderTimeoutLease.Milliseconds()
+	for _, orderID := range orderIDs {
+		// 2. 认领订单
+		claimed, err := s.orderDeadline.ClaimOrderDeadline(ctx, orderID, now, leaseUntil)
+		if err != nil {
+			logger.Error("CancelTimeoutOrders ClaimOrderDeadline error", zap.Error(err), zap.Int64("order_id", orderID))
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		// 3. 取消订单
+		s.cancelTimeoutOrder(ctx, orderID)
+	}
+	return len(orderIDs), common.OK
+}
+
+// cancelTimeoutOrder 取消超时未支付的订单
+// 参数:
+//   - ctx: 上下文
+//   - orderID: 已认领的订单ID
+//
+// 处理结果:
+//   - 取消成功: 记录日志,移除截止时间
+//   - 订单不存在、已支付或状态已变化: 跳过,移除截止时间
+//   - 其他错误: 保留截止时间,租约到期后重新处理
+func (s *Service) cancelTimeoutOrder(ctx context.Context, orderID int64) {
+	errno := s.orderState.Transit(ctx, &do.TransitOrder{
+		OrderID:      orderID,
+		ToStatus:     consts.OrderStatusCancelled,
+		OperatorType: consts.OrderOperatorSystem,
+		OperatorID:   consts.SystemOperatorID, // 系统取消
+		Remark:       "超时未支付，系统自动取消",
+		CancelType:   consts.OrderCancelByTimeout,
+	})
+	switch errno.Code {
+	case common.OK.Code:
+		logger.Info("cancelTimeoutOrder", zap.Int64("order_id", orderID))
+	case common.DataNotFoundErr.Code, common.OrderStatusErr.Code, common.OrderChangedErr.Code:
+		logger.Info("cancelTimeoutOrder skipped", zap.Int64("order_id", orderID), zap.String("reason", errno.Msg))
+	default:
+		logger.Warn("cancelTimeoutOrder retry later", zap.Int64("order_id", orderID), zap.String("reason", errno.ErrMsg))
+		return
+	}
+	if err := s.orderDeadline.RemoveOrderDeadline(ctx, orderID); err != nil {
+		logger.Error("cancelTimeoutOrder RemoveOrderDeadline error", zap.Error(err), zap.Int64("order_id", orderID))
+	}
+}
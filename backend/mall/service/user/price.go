@@ -0,0 +1,76 @@
+// Package user 前台用户业务逻辑层-课程商品计价
+// 职责: 解析课程商品当前生效的价格,展示和下单统一使用
+// 规则:
+//   - 免费商品价格固定为0
+//   - 有生效的价格记录时使用价格记录,否则沿用course_goods.course_price(价格历史上线前的商品)
+package user
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"mall/adaptor/repo/model"
+	"mall/common"
+	"mall/consts"
+	"mall/utils/logger"
+	"time"
+)
+
+// resolveGoodsPrice 解析课程商品在指定时间的价格
+// 参数:
+//   - ctx: 上下文
+//   - g: 课程商品
+//   - now: 计价时间
+//
+// 返回: 价格(单位分)和错误码
+// 调用链: service.GetCourseDetail/下单 -> resolveGoodsPrice -> repo.GetEffectivePrice
+func (s *Service) resolveGoodsPrice(ctx context.Context, g *model.CourseGood, now time.Time) (int64, common.Errno) {
+	if g.SaleType == consts.SaleTypeFree {
+		return 0, common.OK
+	}
+	price, err := s.price.GetEffectivePrice(ctx, g.ID, now)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return g.CoursePrice, common.OK
+		}
+		logger.Error("resolveGoodsPrice GetEffectivePrice error", zap.Error(err), zap.Int64("goods_id", g.ID))
+		return 0, common.DatabaseErr.WithErr(err)
+	}
+	return price.Price, common.OK
+}
+
+// resolveGoodsPrices 批量解析课程商品在指定时间的价格
+// 参数:
+//   - ctx: 上下文
+//   - goods: 课程商品列表
+//   - now: 计价时间
+//
+// 返回: 商品ID到价格(单位分)的映射和错误码
+// 调用链: service.SearchGoods -> resolveGoodsPrices -> repo.ListEffectivePrices
+func (s *Service) resolveGoodsPrices(ctx context.Context, goods []*model.CourseGood, now time.Time) (map[int64]int64, common.Errno) {
+	result := make(map[int64]int64, len(goods))
+	goodsIDs := make([]int64, 0, len(goods))
+	for _, g := range goods {
+		if g.SaleType == consts.SaleTypeFree {
+			result[g.ID] = 0
+			continue
+		}
+		result[g.ID] = g.CoursePrice
+		goodsIDs = append(goodsIDs, g.ID)
+	}
+	prices, err := s.price.ListEffectivePrices(ctx, goodsIDs, now)
+	if err != nil {
+		logger.Error("resolveGoodsPrices ListEffectivePrices error", zap.Error(err), zap.Int64s("goods_ids", goodsIDs))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	resolved := make(map[int64]bool, len(goodsIDs))
+	for _, price := range prices {
+		if resolved[price.GoodsID] { // 同一商品已按生效时间倒序,只取第一条
+			continue
+		}
+		resolved[price.GoodsID] = true
+		result[price.GoodsID] = price.Price
+	}
+	return result, common.OK
+}
@@ -0,0 +1,52 @@
+// Package user 前台用户业务逻辑层
+// 职责: 实现前台用户相关的业务逻辑
+// 依赖: user(数据访问) + token(JWT签发校验) + verify/frequency(登录防刷) + goods/catalog/lesson/price(课程) + order(订单) + orderState(订单状态机) + orderDeadline(订单超时)
+package user
+
+import (
+	"mall/adaptor"
+	"mall/adaptor/redis"
+	"mall/adaptor/repo/course"
+	"mall/adaptor/repo/order"
+	"mall/adaptor/repo/user"
+	"mall/service/orderstate"
+	"mall/utils/token"
+	"time"
+)
+
+// Service 前台用户服务结构体
+type Service struct {
+	user          user.IUser               // 前台用户数据访问接口
+	token         *token.Jwt               // 用户Token签发校验器,与管理后台使用不同的密钥和接收方
+	verify        redis.IVerify            // 验证码Redis操作接口
+	frequency     redis.IFrequency         // 频率控制Redis操作接口
+	goods         course.ICourseGoods      // 课程商品数据访问接口
+	catalog       course.ICourseCatalog    // 课程目录数据访问接口
+	lesson        course.ICourseLesson     // 课程课时数据访问接口
+	price         course.ICourseGoodsPrice // 课程商品价格数据访问接口
+	order         order.IOrder             // 订单数据访问接口
+	orderState    *orderstate.Machine      // 订单状态机
+	orderDeadline redis.IOrderDeadline     // 订单超时Redis操作接口
+	payTimeout    time.Duration            // 未支付订单自动取消时间
+}
+
+// NewService 创建前台用户服务实例
+// 参数: adaptor 适配器,提供数据库和Redis访问
+// 返回: Service实例
+// 调用链: api/customer.NewCtrl -> NewService
+func NewService(adaptor adaptor.IAdaptor) *Service {
+	return &Service{
+		user:          user.NewUser(adaptor),                            // 初始化用户数据访问
+		token:         token.NewJwt(adaptor.GetConfig().Token.Customer), // 初始化用户Token签发校验器
+		verify:        redis.NewVerify(adaptor),                         // 初始化验证码Redis操作
+		frequency:     redis.NewFrequency(adaptor),                      // 初始化频率控制Redis操作
+		goods:         course.NewCourseGoods(adaptor),                   // 初始化课程商品数据访问
+		catalog:       course.NewCourseCatalog(adaptor),                 // 初始化课程目录数据访问
+		lesson:        course.NewCourseLesson(adaptor),                  // 初始化课程课时数据访问
+		price:         course.NewCourseGoodsPrice(adaptor),              // 初始化课程商品价格数据访问
+		order:         order.NewOrder(adaptor),                          // 初始化订单数据访问
+		orderState:    orderstate.NewMachine(adaptor),                   // 初始化订单状态机
+		orderDeadline: redis.NewOrderDeadline(adaptor),                  // 初始化订单超时Redis操作
+		payTimeout:    adaptor.GetConfig().Order.GetPayTimeout(),        // 读取未支付订单自动取消时间
+	}
+}
@@ -1 +1,96 @@
+// Package user 前台用户业务逻辑层-用户
+// 职责: 用户Token签发校验、用户信息查询
 package user
+
+import (
+	"context"
+	"errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"mall/adaptor/repo/model"
+	"mall/common"
+	"mall/consts"
+	"mall/service/dto"
+	"mall/utils/logger"
+	"mall/utils/token"
+)
+
+// genToken 为用户签发Token
+// 参数: user 用户
+// 返回: Token字符串、有效期(秒)和错误码
+// 调用链: service.XxxLogin -> genToken
+func (s *Service) genToken(user *model.User) (string, int64, common.Errno) {
+	tokenStr, _, err := s.token.Sign(user.ID, user.NickName)
+	if err != nil {
+		logger.Error("genToken Sign error", zap.Error(err), zap.Int64("user_id", user.ID))
+		return "", 0, common.ServerErr.WithErr(err)
+	}
+	return tokenStr, int64(s.token.Expire().Seconds()), common.OK
+}
+
+// ParseToken 解析用户Token
+// 参数:
+//   - ctx: 上下文
+//   - tokenStr: 请求头中的Token
+//
+// 返回: 用户信息和错误码
+// 业务流程:
+//  1. 使用用户侧密钥校验签名、过期时间、签发者和接收方(管理后台Token无法通过)
+//  2. 查询用户最新状态
+//  3. 已禁用的用户即使Token有效也拒绝访问
+//
+// 调用链: router.AuthMiddleware -> api/customer.ParseToken -> service.ParseToken
+func (s *Service) ParseToken(ctx context.Context, tokenStr string) (*common.User, common.Errno) {
+	// 1. 校验Token
+	claims, err := s.token.Parse(tokenStr)
+	if err != nil {
+		if token.IsExpired(err) {
+			return nil, common.TokenExpiredErr
+		}
+		return nil, common.TokenInvalidErr.WithErr(err)
+	}
+
+	// 2. 查询用户最新状态
+	user, err := s.user.GetUserInfo(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.TokenInvalidErr
+		}
+		logger.Error("ParseToken GetUserInfo error", zap.Error(err), zap.Int64("user_id", claims.UserID))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+
+	// 3. 校验账号状态
+	if user.Status == consts.IsDisable {
+		return nil, common.UserDisabledErr
+	}
+
+	return &common.User{
+		UserID:   user.ID,
+		NickName: user.NickName,
+	}, common.OK
+}
+
+// GetUserInfo 获取用户详细信息
+// 参数:
+//   - ctx: 上下文
+//   - user: 当前登录的用户
+//
+// 返回: 用户信息DTO和错误码
+// 调用链: api/customer.GetUserInfo -> service.GetUserInfo -> repo.GetUserInfo
+func (s *Service) GetUserInfo(ctx context.Context, user *common.User) (*dto.CustomerUserInfoResp, common.Errno) {
+	info, err := s.user.GetUserInfo(ctx, user.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, common.UserNotFoundErr
+		}
+		logger.Error("GetUserInfo GetUserInfo error", zap.Error(err), zap.Any("user", user))
+		return nil, common.DatabaseErr.WithErr(err)
+	}
+	return &dto.CustomerUserInfoResp{
+		UserID:   info.ID,
+		NickName: info.NickName,
+		Sex:      info.Sex,
+		IconKey:  info.IconKey,
+	}, common.OK
+}
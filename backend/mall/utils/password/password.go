@@ -0,0 +1,188 @@
+// Package password 密码哈希工具模块
+// 职责: 密码的加盐哈希与校验,哈希值自描述算法和参数,便于平滑升级
+// 支持格式:
+//   - argon2id: $argon2id$v=19$m=65536,t=3,p=4$<salt>$<hash>(PHC格式,新密码默认使用)
+//   - bcrypt: $2a$10$...(bcrypt标准格式)
+//   - 历史SHA256: 64位十六进制,只校验不生成,校验通过后应重新哈希
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mall/utils/tools"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// argon2id参数,参考RFC 9106推荐配置
+const (
+	argonTime    = 3         // 迭代次数
+	argonMemory  = 64 * 1024 // 内存(KiB)
+	argonThreads = 4         // 并行度
+	argonKeyLen  = 32        // 哈希长度(字节)
+	argonSaltLen = 16        // 盐长度(字节)
+)
+
+// 存储的argon2id参数上限,超出范围视为哈希损坏,校验直接失败
+// 防止损坏的数据导致argon2.IDKey panic(t=0或p=0)或分配过多内存
+const (
+	argonMaxTime    = 10         // 最大迭代次数
+	argonMaxMemory  = 256 * 1024 // 最大内存(KiB)
+	argonMaxThreads = 16         // 最大并行度
+	argonMinKeyLen  = 16         // 最小哈希长度(字节)
+	argonMaxKeyLen  = 64         // 最大哈希长度(字节)
+	argonMinSaltLen = 8          // 最小盐长度(字节)
+)
+
+const legacyLen = 64 // 历史SHA256哈希长度
+
+var (
+	ErrUnknownFormat = errors.New("password: unknown hash format")   // 无法识别的哈希格式
+	ErrInvalidParams = errors.New("password: invalid argon2 params") // argon2id参数超出允许范围
+)
+
+var (
+	dummyHash     string    // 防枚举用的argon2id哈希,首次使用时生成
+	dummyHashOnce sync.Once // 保证dummyHash只生成一次
+)
+
+// argonParams argon2id哈希参数
+type argonParams struct {
+	time    uint32
+	memory  uint32
+	threads uint8
+	salt    []byte
+	key     []byte
+}
+
+// Hash 对密码加盐哈希
+// 参数: password 密码明文
+// 返回: argon2id自描述哈希字符串和错误
+func Hash(password string) (string, error) {
+	salt := make([]byte, argonSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, argonTime, argonMemory, argonThreads, argonKeyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, argonMemory, argonTime, argonThreads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Verify 校验密码
+// 参数:
+//   - hashed: 存储的密码哈希,为空表示未设置密码
+//   - password: 用户输入的密码明文
+//
+// 返回:
+//   - ok: 是否匹配
+//   - needRehash: 匹配但哈希不是当前默认算法和参数(历史SHA256、bcrypt、旧argon2id参数),调用方应重新哈希并保存
+//
+// 防枚举: hashed为空(账号不存在或未设置密码)时同样执行一次argon2id计算,结果恒为不匹配,
+// 使耗时与账号存在时一致,调用方在账号不存在时应传入空字符串调用
+func Verify(hashed, password string) (ok bool, needRehash bool) {
+	switch {
+	case hashed == "":
+		if dummy := getDummyHash(); dummy != "" {
+			Verify(dummy, password)
+		}
+		return false, false
+	case strings.HasPrefix(hashed, "$argon2id$"):
+		params, err := parseArgon2id(hashed)
+		if err != nil {
+			return false, false
+		}
+		key := argon2.IDKey([]byte(password), params.salt, params.time, params.memory, params.threads, uint32(len(params.key)))
+		if subtle.ConstantTimeCompare(key, params.key) != 1 {
+			return false, false
+		}
+		outdated := params.time != argonTime || params.memory != argonMemory ||
+			params.threads != argonThreads || len(params.key) != argonKeyLen
+		return true, outdated
+	case strings.HasPrefix(hashed, "$2"):
+		if bcrypt.CompareHashAndPassword([]byte(hashed), []byte(password)) != nil {
+			return false, false
+		}
+		return true, true
+	case isLegacy(hashed):
+		if subtle.ConstantTimeCompare([]byte(tools.Sha256Hash(password)), []byte(strings.ToLower(hashed))) != 1 {
+			return false, false
+		}
+		return true, true
+	default:
+		return false, false
+	}
+}
+
+// isLegacy 判断是否为历史的无盐SHA256哈希(64位十六进制)
+func isLegacy(hashed string) bool {
+	if len(hashed) != legacyLen {
+		return false
+	}
+	_, err := hex.DecodeString(hashed)
+	return err == nil
+}
+
+// parseArgon2id 解析argon2id哈希字符串
+// 格式: $argon2id$v=19$m=65536,t=3,p=4$<salt>$<hash>
+// 校验: 参数超出允许范围时返回ErrInvalidParams
+func parseArgon2id(hashed string) (*argonParams, error) {
+	parts := strings.Split(hashed, "$")
+	if len(parts) != 6 {
+		return nil, ErrUnknownFormat
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return nil, err
+	}
+	if version != argon2.Version {
+		return nil, fmt.Errorf("password: unsupported argon2 version %d", version)
+	}
+
+	params := &argonParams{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.threads); err != nil {
+		return nil, err
+	}
+	var err error
+	if params.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, err
+	}
+	if params.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		return nil, err
+	}
+	if err = params.check(); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// check 校验argon2id参数在允许范围内
+// 规则: 迭代次数1-10,并行度1-16,内存不少于8*并行度且不超过256MiB,哈希16-64字节,盐不少于8字节
+func (p *argonParams) check() error {
+	if p.time < 1 || p.time > argonMaxTime ||
+		p.threads < 1 || p.threads > argonMaxThreads ||
+		p.memory < 8*uint32(p.threads) || p.memory > argonMaxMemory ||
+		len(p.key) < argonMinKeyLen || len(p.key) > argonMaxKeyLen ||
+		len(p.salt) < argonMinSaltLen {
+		return ErrInvalidParams
+	}
+	return nil
+}
+
+// getDummyHash 获取防枚举用的argon2id哈希
+// 使用当前默认参数生成,与真实密码哈希的校验耗时一致
+func getDummyHash() string {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = Hash(tools.UUIDHex())
+	})
+	return dummyHash
+}
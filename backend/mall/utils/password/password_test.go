@@ -0,0 +1,68 @@
+package password
+
+import (
+	"encoding/base64"
+	"fmt"
+	"mall/utils/tools"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// argon2idHash 按指定参数生成argon2id哈希字符串
+func argon2idHash(password string, memory, time uint32, threads uint8) string {
+	salt := []byte("0123456789abcdef")
+	key := argon2.IDKey([]byte(password), salt, time, memory, threads, argonKeyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, memory, time, threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key))
+}
+
+func TestVerify(t *testing.T) {
+	current, err := Hash("secret123")
+	if err != nil {
+		t.Fatalf("Hash error: %v", err)
+	}
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte("secret123"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("bcrypt error: %v", err)
+	}
+	salt := base64.RawStdEncoding.EncodeToString([]byte("0123456789abcdef"))
+	key := base64.RawStdEncoding.EncodeToString(make([]byte, argonKeyLen))
+
+	cases := []struct {
+		name       string
+		hashed     string
+		password   string
+		ok         bool
+		needRehash bool
+	}{
+		{"argon2id current", current, "secret123", true, false},
+		{"argon2id wrong password", current, "secret124", false, false},
+		{"argon2id outdated params", argon2idHash("secret123", 8*1024, 1, 1), "secret123", true, true},
+		{"bcrypt", string(bcryptHash), "secret123", true, true},
+		{"bcrypt wrong password", string(bcryptHash), "secret124", false, false},
+		{"legacy sha256", tools.Sha256Hash("secret123"), "secret123", true, true},
+		{"legacy sha256 uppercase", strings.ToUpper(tools.Sha256Hash("secret123")), "secret123", true, true},
+		{"legacy sha256 wrong password", tools.Sha256Hash("secret123"), "secret124", false, false},
+		{"empty hash", "", "secret123", false, false},
+		{"unknown format", "plain-text", "plain-text", false, false},
+		{"argon2id zero threads", fmt.Sprintf("$argon2id$v=19$m=65536,t=3,p=0$%s$%s", salt, key), "secret123", false, false},
+		{"argon2id zero time", fmt.Sprintf("$argon2id$v=19$m=65536,t=0,p=4$%s$%s", salt, key), "secret123", false, false},
+		{"argon2id huge memory", fmt.Sprintf("$argon2id$v=19$m=4294967295,t=3,p=4$%s$%s", salt, key), "secret123", false, false},
+		{"argon2id short key", fmt.Sprintf("$argon2id$v=19$m=65536,t=3,p=4$%s$%s", salt, base64.RawStdEncoding.EncodeToString([]byte("k"))), "secret123", false, false},
+		{"argon2id wrong version", fmt.Sprintf("$argon2id$v=16$m=65536,t=3,p=4$%s$%s", salt, key), "secret123", false, false},
+		{"argon2id missing part", "$argon2id$v=19$m=65536,t=3,p=4$" + salt, "secret123", false, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ok, needRehash := Verify(c.hashed, c.password)
+			if ok != c.ok || needRehash != c.needRehash {
+				t.Errorf("Verify() = (%v, %v), want (%v, %v)", ok, needRehash, c.ok, c.needRehash)
+			}
+		})
+	}
+}
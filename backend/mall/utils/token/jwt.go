@@ -0,0 +1,137 @@
+// Package token 令牌工具模块
+// 职责: JWT的签发与校验,支持HS256(对称密钥)和RS256(非对称密钥)两种签名算法
+// 特性: 校验签名、过期时间、签发者和接收方,每个Token携带唯一ID(jti)便于会话管理
+package token
+
+import (
+	"errors"
+	"fmt"
+	"mall/config"
+	"mall/utils/tools"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const defaultExpire = 7200 // 默认有效期(秒)
+
+// Claims Token载荷
+// 在标准声明基础上附加用户ID和名称
+type Claims struct {
+	UserID int64  `json:"uid"`  // 用户ID
+	Name   string `json:"name"` // 用户名称
+	jwt.RegisteredClaims
+}
+
+// Jwt JWT签发与校验器
+type Jwt struct {
+	method    jwt.SigningMethod // 签名算法
+	signKey   any               // 签名密钥: HS256为[]byte,RS256为*rsa.PrivateKey
+	verifyKey any               // 校验密钥: HS256为[]byte,RS256为*rsa.PublicKey
+	issuer    string            // 签发者
+	audience  string            // 接收方
+	expire    time.Duration     // 有效期
+}
+
+// NewJwt 创建JWT签发与校验器
+// 参数: conf JWT配置
+// 返回: Jwt实例
+// 注意: 密钥配置错误或接收方为空时直接panic,保证服务启动阶段即暴露问题
+// 调用: service/admin.NewService、service/user.NewService -> NewJwt
+func NewJwt(conf config.Jwt) *Jwt {
+	j := &Jwt{
+		issuer:   conf.Issuer,
+		audience: conf.Audience,
+		expire:   time.Duration(conf.Expire) * time.Second,
+	}
+	if conf.Audience == "" {
+		panic("jwt: audience is required")
+	}
+	if conf.Expire <= 0 {
+		j.expire = defaultExpire * time.Second
+	}
+
+	switch strings.ToUpper(conf.Method) {
+	case "", jwt.SigningMethodHS256.Alg():
+		if conf.Secret == "" {
+			panic("jwt: secret is required for HS256")
+		}
+		j.method = jwt.SigningMethodHS256
+		j.signKey = []byte(conf.Secret)
+		j.verifyKey = []byte(conf.Secret)
+	case jwt.SigningMethodRS256.Alg():
+		priKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(conf.PrivateKey))
+		if err != nil {
+			panic(fmt.Sprintf("jwt: invalid rsa private key: %v", err))
+		}
+		pubKey, err := jwt.ParseRSAPublicKeyFromPEM([]byte(conf.PublicKey))
+		if err != nil {
+			panic(fmt.Sprintf("jwt: invalid rsa public key: %v", err))
+		}
+		j.method = jwt.SigningMethodRS256
+		j.signKey = priKey
+		j.verifyKey = pubKey
+	default:
+		panic(fmt.Sprintf("jwt: unsupported signing method %s", conf.Method))
+	}
+	return j
+}
+
+// Sign 签发Token
+// 参数:
+//   - userID: 用户ID
+//   - name: 用户名称
+//
+// 返回: Token字符串、载荷(含jti和过期时间)和错误
+func (j *Jwt) Sign(userID int64, name string) (string, *Claims, error) {
+	now := time.Now()
+	claims := &Claims{
+		UserID: userID,
+		Name:   name,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tools.UUIDHex(), // 唯一ID,用于会话管理
+			Issuer:    j.issuer,
+			Audience:  jwt.ClaimStrings{j.audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(j.expire)),
+		},
+	}
+	tokenStr, err := jwt.NewWithClaims(j.method, claims).SignedString(j.signKey)
+	if err != nil {
+		return "", nil, err
+	}
+	return tokenStr, claims, nil
+}
+
+// Parse 解析并校验Token
+// 参数: tokenStr Token字符串
+// 返回: 载荷和错误
+// 校验项: 签名算法、签名、过期时间、签发者、接收方
+// 过期时返回的错误可通过IsExpired判断
+func (j *Jwt) Parse(tokenStr string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(tokenStr, claims, func(t *jwt.Token) (any, error) {
+		return j.verifyKey, nil
+	},
+		jwt.WithValidMethods([]string{j.method.Alg()}), // 限定算法,防止算法替换攻击
+		jwt.WithIssuer(j.issuer),
+		jwt.WithAudience(j.audience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// Expire 获取Token有效期
+func (j *Jwt) Expire() time.Duration {
+	return j.expire
+}
+
+// IsExpired 判断错误是否为Token过期
+func IsExpired(err error) bool {
+	return errors.Is(err, jwt.ErrTokenExpired)
+}
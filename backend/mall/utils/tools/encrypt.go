@@ -10,7 +10,8 @@ import (
 // Sha256Hash SHA256哈希计算
 // 参数: text 待哈希的明文字符串
 // 返回: 64位十六进制哈希字符串
-// 用途: 手机号加密存储、密码哈希等
+// 用途: 手机号全值搜索等
+// 注意: 无盐哈希,不可用于密码存储,密码请使用utils/password
 func Sha256Hash(text string) string {
 	hash := sha256.New()
 	hash.Write([]byte(text))
@@ -1,9 +1,11 @@
 // Package tools 通用工具函数模块
-// 职责: 提供UUID生成等通用工具函数
+// 职责: 提供UUID生成、随机数字串、LIKE转义等通用工具函数
 package tools
 
 import (
+	"crypto/rand"
 	"github.com/google/uuid"
+	"math/big"
 	"strings"
 )
 
@@ -13,3 +15,28 @@ import (
 func UUIDHex() string {
 	return strings.ReplaceAll(uuid.New().String(), "-", "")
 }
+
+// RandomDigits 生成指定长度的随机数字串
+// 参数: n 数字位数
+// 返回: 由0-9组成的字符串,可能以0开头
+// 用途: 短信验证码等需要不可预测的场景,使用crypto/rand生成
+func RandomDigits(n int) string {
+	var sb strings.Builder
+	sb.Grow(n)
+	for i := 0; i < n; i++ {
+		d, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			panic(err)
+		}
+		sb.WriteByte(byte('0' + d.Int64()))
+	}
+	return sb.String()
+}
+
+// EscapeLike 转义LIKE通配符
+// 参数: s 用户输入的关键字
+// 返回: 转义后的字符串,调用方再自行拼接%
+// 用途: 模糊查询时避免用户输入的%和_被当作通配符
+func EscapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}